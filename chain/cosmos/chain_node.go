@@ -203,6 +203,24 @@ func (tn *ChainNode) HostName() string {
 	return dockerutil.CondenseHostName(tn.Name())
 }
 
+// HostRPCAddress returns the address of the node's RPC server accessible by the host.
+// This will not return a valid address until the node has been started.
+func (tn *ChainNode) HostRPCAddress() string {
+	return "http://" + tn.hostRPCPort
+}
+
+// HostAPIAddress returns the address of the node's REST API server accessible by the host.
+// This will not return a valid address until the node has been started.
+func (tn *ChainNode) HostAPIAddress() string {
+	return "http://" + tn.hostAPIPort
+}
+
+// HostGRPCAddress returns the address of the node's gRPC server accessible by the host.
+// This will not return a valid address until the node has been started.
+func (tn *ChainNode) HostGRPCAddress() string {
+	return tn.hostGRPCPort
+}
+
 func (tn *ChainNode) GenesisFileContent(ctx context.Context) ([]byte, error) {
 	gen, err := tn.ReadFile(ctx, "config/genesis.json")
 	if err != nil {
@@ -1355,6 +1373,85 @@ func (tn *ChainNode) AccountKeyBech32(ctx context.Context, name string) (string,
 	return tn.KeyBech32(ctx, name, "")
 }
 
+// ValidatorOperatorAddress retrieves the operator address of the node's validator key in bech32 format.
+func (tn *ChainNode) ValidatorOperatorAddress(ctx context.Context) (string, error) {
+	return tn.KeyBech32(ctx, valKey, "val")
+}
+
+// ConsensusPubKey returns the node's consensus public key, as the JSON printed by show-validator.
+func (tn *ChainNode) ConsensusPubKey(ctx context.Context) (string, error) {
+	command := []string{tn.Chain.Config().Bin}
+	if tn.IsAboveSDK47(ctx) {
+		command = append(command, "comet")
+	} else {
+		command = append(command, "tendermint")
+	}
+
+	command = append(command, "show-validator", "--home", tn.HomeDir())
+
+	stdout, stderr, err := tn.Exec(ctx, command, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to show validator (stderr=%q): %w", stderr, err)
+	}
+
+	return string(bytes.TrimSpace(stdout)), nil
+}
+
+// CreateValidator submits a create-validator transaction for the node's consensus key,
+// signed by the node's validator key and self-delegating selfDelegation.
+// The validator key must exist and hold enough funds for the self-delegation and the fees.
+func (tn *ChainNode) CreateValidator(ctx context.Context, selfDelegation types.Coin) error {
+	pubKey, err := tn.ConsensusPubKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	amount := fmt.Sprintf("%s%s", selfDelegation.Amount.String(), selfDelegation.Denom)
+	moniker := CondenseMoniker(tn.Name())
+
+	var command []string
+	if tn.createValidatorFromFile(ctx) {
+		// Since SDK v0.50, the validator is read from a JSON file.
+		file := "create-validator.json"
+		validator, err := json.MarshalIndent(map[string]any{
+			"pubkey":                     json.RawMessage(pubKey),
+			"amount":                     amount,
+			"moniker":                    moniker,
+			"commission-rate":            "0.1",
+			"commission-max-rate":        "0.2",
+			"commission-max-change-rate": "0.01",
+			"min-self-delegation":        "1",
+		}, "", " ")
+		if err != nil {
+			return err
+		}
+		if err := tn.WriteFile(ctx, validator, file); err != nil {
+			return fmt.Errorf("writing validator file to docker volume: %w", err)
+		}
+		command = []string{"staking", "create-validator", path.Join(tn.HomeDir(), file)}
+	} else {
+		command = []string{"staking", "create-validator",
+			"--pubkey", pubKey,
+			"--amount", amount,
+			"--moniker", moniker,
+			"--commission-rate", "0.1",
+			"--commission-max-rate", "0.2",
+			"--commission-max-change-rate", "0.01",
+			"--min-self-delegation", "1",
+		}
+	}
+
+	_, err = tn.ExecTx(ctx, valKey, append(command, "--gas", "auto")...)
+	return err
+}
+
+// createValidatorFromFile reports whether the create-validator command of the chain binary
+// takes the validator as a JSON file instead of flags.
+func (tn *ChainNode) createValidatorFromFile(ctx context.Context) bool {
+	stdout, _, err := tn.ExecBin(ctx, "tx", "staking", "create-validator", "--help")
+	return err == nil && bytes.Contains(stdout, []byte("validator.json"))
+}
+
 // PeerString returns the string for connecting the nodes passed in
 func (nodes ChainNodes) PeerString(ctx context.Context) string {
 	addrs := make([]string, len(nodes))
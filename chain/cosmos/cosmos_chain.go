@@ -25,6 +25,7 @@ import (
 	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	paramsutils "github.com/cosmos/cosmos-sdk/x/params/client/utils"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	cosmosproto "github.com/cosmos/gogoproto/proto"
 	chanTypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
 	dockertypes "github.com/docker/docker/api/types"
@@ -52,12 +53,17 @@ type CosmosChain struct {
 	Validators    ChainNodes
 	FullNodes     ChainNodes
 
+	// Number of validators removed with RemoveValidator or discarded by a failed AddValidators.
+	numRemovedValidators int
+
 	// Additional processes that need to be run on a per-chain basis.
 	Sidecars SidecarProcesses
 
 	log      *zap.Logger
 	keyring  keyring.Keyring
 	findTxMu sync.Mutex
+	// nodesMu guards the node sets, Validators and FullNodes, while nodes are added or removed.
+	nodesMu sync.Mutex
 }
 
 func NewCosmosHeighlinerChainConfig(name string,
@@ -113,6 +119,23 @@ func (c *CosmosChain) Nodes() ChainNodes {
 	return append(c.Validators, c.FullNodes...)
 }
 
+// NodeContainers returns the containers of all nodes, validators first,
+// each with the containers of its sidecar processes.
+func (c *CosmosChain) NodeContainers() []ibc.NodeContainers {
+	nodes := c.Nodes()
+	containers := make([]ibc.NodeContainers, len(nodes))
+	for i, n := range nodes {
+		containers[i] = ibc.NodeContainers{
+			Validator:  n.Validator,
+			Containers: []string{n.Name()},
+		}
+		for _, s := range n.Sidecars {
+			containers[i].Containers = append(containers[i].Containers, s.Name())
+		}
+	}
+	return containers
+}
+
 // AddFullNodes adds new fullnodes to the network, peering with the existing nodes.
 func (c *CosmosChain) AddFullNodes(ctx context.Context, configFileOverrides map[string]any, inc int) error {
 	// Get peer string for existing nodes
@@ -170,6 +193,245 @@ func (c *CosmosChain) AddFullNodes(ctx context.Context, configFileOverrides map[
 	return eg.Wait()
 }
 
+// AddValidators adds new validators to the running network.
+// The new nodes peer with the existing nodes and block sync the chain. Once they are in sync, each one is funded by the first validator
+// and submits a create-validator transaction self-delegating selfDelegation.
+// AddValidators returns once the new validators are in the active set, so selfDelegation must be large enough to enter it.
+// Each new node joins Validators once it is bonded. If AddValidators fails, the new nodes that did not get bonded are removed.
+func (c *CosmosChain) AddValidators(ctx context.Context, configFileOverrides map[string]any, inc int, selfDelegation types.Coin) (err error) {
+	if inc <= 0 {
+		return fmt.Errorf("number of validators to add must be positive, got %d", inc)
+	}
+
+	c.nodesMu.Lock()
+	if len(c.Validators) == 0 {
+		c.nodesMu.Unlock()
+		return fmt.Errorf("chain %s has no validator to fund the new validators", c.cfg.ChainID)
+	}
+	funder := c.Validators[0]
+	// Count removed validators, so that node names are not reused.
+	nextIndex := len(c.Validators) + c.numRemovedValidators
+	c.nodesMu.Unlock()
+
+	// Get peer string for existing nodes
+	peers := c.Nodes().PeerString(ctx)
+
+	// Get genesis.json
+	genbz, err := funder.GenesisFileContent(ctx)
+	if err != nil {
+		return err
+	}
+
+	cli, networkID := funder.DockerClient, funder.NetworkID
+	image := c.Config().Images[0]
+
+	// newVals holds the new nodes once their container is created, and bonded records which of them became validators.
+	newVals := make(ChainNodes, inc)
+	bonded := make([]bool, inc)
+	defer func() {
+		if err == nil {
+			return
+		}
+		discarded := 0
+		for i, val := range newVals {
+			if bonded[i] {
+				continue
+			}
+			discarded++
+			if val == nil {
+				continue
+			}
+			if rmErr := val.RemoveContainer(ctx); rmErr != nil {
+				c.log.Info("Failed to remove unbonded validator node", zap.String("node", val.Name()), zap.Error(rmErr))
+			}
+		}
+		// The names of the discarded nodes are not reused either.
+		c.nodesMu.Lock()
+		c.numRemovedValidators += discarded
+		c.nodesMu.Unlock()
+	}()
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	for i := 0; i < inc; i++ {
+		i := i
+		eg.Go(func() error {
+			val, err := c.NewChainNode(egCtx, c.testName, cli, networkID, image, true, nextIndex+i)
+			if err != nil {
+				return err
+			}
+			if err := val.InitFullNodeFiles(egCtx); err != nil {
+				return err
+			}
+			if err := val.SetPeers(egCtx, peers); err != nil {
+				return err
+			}
+			if err := val.OverwriteGenesisFile(egCtx, genbz); err != nil {
+				return err
+			}
+			for configFile, modifiedConfig := range configFileOverrides {
+				modifiedToml, ok := modifiedConfig.(testutil.Toml)
+				if !ok {
+					return fmt.Errorf("Provided toml override for file %s is of type (%T). Expected (DecodedToml)", configFile, modifiedConfig)
+				}
+				if err := testutil.ModifyTomlConfigFile(
+					egCtx,
+					val.logger(),
+					val.DockerClient,
+					val.TestName,
+					val.VolumeName,
+					configFile,
+					modifiedToml,
+				); err != nil {
+					return err
+				}
+			}
+			if err := val.CreateKey(egCtx, valKey); err != nil {
+				return err
+			}
+			if err := val.CreateNodeContainer(egCtx); err != nil {
+				return err
+			}
+			newVals[i] = val
+			return val.StartContainer(egCtx)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	// The new nodes can only check and broadcast transactions once they have caught up with the chain.
+	for _, val := range newVals {
+		if err := testutil.WaitForInSync(ctx, c, val); err != nil {
+			return fmt.Errorf("failed to sync validator %s: %w", val.Name(), err)
+		}
+	}
+
+	// Fund the validator keys one at a time, since they are all funded from the same account.
+	// The extra funds pay the fees of the create-validator transaction.
+	fees := sdkmath.NewInt(c.GetGasFeesInNativeDenom(1_000_000))
+	for _, val := range newVals {
+		bech32, err := val.AccountKeyBech32(ctx, valKey)
+		if err != nil {
+			return err
+		}
+		if err := funder.SendFunds(ctx, valKey, ibc.WalletAmount{
+			Address: bech32,
+			Denom:   selfDelegation.Denom,
+			Amount:  selfDelegation.Amount.Add(fees),
+		}); err != nil {
+			return fmt.Errorf("failed to fund validator %s: %w", val.Name(), err)
+		}
+	}
+
+	eg, egCtx = errgroup.WithContext(ctx)
+	for i, val := range newVals {
+		i, val := i, val
+		eg.Go(func() error {
+			if err := val.CreateValidator(egCtx, selfDelegation); err != nil {
+				return fmt.Errorf("failed to create validator %s: %w", val.Name(), err)
+			}
+			valoper, err := val.ValidatorOperatorAddress(egCtx)
+			if err != nil {
+				return err
+			}
+			if err := c.waitForValidatorStatus(egCtx, valoper, func(status stakingtypes.BondStatus) bool {
+				return status == stakingtypes.Bonded
+			}); err != nil {
+				return err
+			}
+
+			c.nodesMu.Lock()
+			c.Validators = append(c.Validators, val)
+			c.numValidators++
+			c.nodesMu.Unlock()
+			bonded[i] = true
+			return nil
+		})
+	}
+	return eg.Wait()
+}
+
+// RemoveValidator unbonds the self-delegation of the validator, waits for it to leave the active set,
+// then stops and removes its node from the network.
+// The remaining validators must hold enough voting power for the chain to keep producing blocks.
+func (c *CosmosChain) RemoveValidator(ctx context.Context, val *ChainNode) error {
+	c.nodesMu.Lock()
+	validators := c.Validators
+	c.nodesMu.Unlock()
+
+	isValidator := false
+	for _, v := range validators {
+		if v == val {
+			isValidator = true
+			break
+		}
+	}
+	if !isValidator {
+		return fmt.Errorf("node %s is not a validator of chain %s", val.Name(), c.cfg.ChainID)
+	}
+	if len(validators) == 1 {
+		return fmt.Errorf("cannot remove the last validator of chain %s", c.cfg.ChainID)
+	}
+
+	valoper, err := val.ValidatorOperatorAddress(ctx)
+	if err != nil {
+		return err
+	}
+	delegator, err := val.AccountKeyBech32(ctx, valKey)
+	if err != nil {
+		return err
+	}
+
+	delegation, err := c.QueryDelegation(ctx, delegator, valoper)
+	if err != nil {
+		return err
+	}
+
+	if err := val.Unbond(ctx, valKey, valoper, delegation.Balance); err != nil {
+		return fmt.Errorf("failed to unbond validator %s: %w", valoper, err)
+	}
+
+	if err := c.waitForValidatorStatus(ctx, valoper, func(status stakingtypes.BondStatus) bool {
+		return status != stakingtypes.Bonded
+	}); err != nil {
+		return err
+	}
+
+	// Look the validator up again, since the node set may have changed while it was unbonding.
+	c.nodesMu.Lock()
+	for i, v := range c.Validators {
+		if v == val {
+			c.Validators = append(c.Validators[:i:i], c.Validators[i+1:]...)
+			c.numValidators--
+			c.numRemovedValidators++
+			break
+		}
+	}
+	c.nodesMu.Unlock()
+
+	if err := val.StopContainer(ctx); err != nil {
+		return err
+	}
+	return val.RemoveContainer(ctx)
+}
+
+// waitForValidatorStatus waits up to 10 blocks for the status of the validator to satisfy done.
+func (c *CosmosChain) waitForValidatorStatus(ctx context.Context, valoper string, done func(stakingtypes.BondStatus) bool) error {
+	return testutil.WaitForBlocksUtil(10, func(int) error {
+		validator, err := c.QueryValidator(ctx, valoper)
+		if err == nil && done(validator.Status) {
+			return nil
+		}
+		if waitErr := testutil.WaitForBlocks(ctx, 1, c); waitErr != nil {
+			return waitErr
+		}
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("validator %s still has status %s", valoper, validator.Status)
+	})
+}
+
 // Implements Chain interface
 func (c *CosmosChain) Config() ibc.ChainConfig {
 	return c.cfg
@@ -184,8 +446,8 @@ func (c *CosmosChain) Initialize(ctx context.Context, testName string, cli *clie
 }
 
 func (c *CosmosChain) getFullNode() *ChainNode {
-	c.findTxMu.Lock()
-	defer c.findTxMu.Unlock()
+	c.nodesMu.Lock()
+	defer c.nodesMu.Unlock()
 	if len(c.FullNodes) > 0 {
 		// use first full node
 		return c.FullNodes[0]
@@ -764,8 +1026,8 @@ func (c *CosmosChain) initializeChainNodes(
 	if err := eg.Wait(); err != nil {
 		return err
 	}
-	c.findTxMu.Lock()
-	defer c.findTxMu.Unlock()
+	c.nodesMu.Lock()
+	defer c.nodesMu.Unlock()
 	c.Validators = newVals
 	c.FullNodes = newFullNodes
 	return nil
@@ -1173,6 +1435,44 @@ func (c *CosmosChain) StartAllNodes(ctx context.Context) error {
 	return eg.Wait()
 }
 
+// StartFromVolumes starts the chain in place of Start, when the home volumes of its nodes
+// already hold the files of a started chain, e.g. restored from a snapshot of another chain with the same config.
+// The nodes keep their keys, genesis and blocks, but their peers are updated to the nodes of this chain.
+func (c *CosmosChain) StartFromVolumes(ctx context.Context) error {
+	chainNodes := c.Nodes()
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, n := range chainNodes {
+		n := n
+		eg.Go(func() error {
+			return n.CreateNodeContainer(egCtx)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	peers := chainNodes.PeerString(ctx)
+
+	eg, egCtx = errgroup.WithContext(ctx)
+	for _, n := range chainNodes {
+		n := n
+		c.log.Info("Starting container", zap.String("container", n.Name()))
+		eg.Go(func() error {
+			if err := n.SetPeers(egCtx, peers); err != nil {
+				return err
+			}
+			return n.StartContainer(egCtx)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	// Wait for 5 blocks before considering the chains "started"
+	return testutil.WaitForBlocks(ctx, 5, c.getFullNode())
+}
+
 // StartAllSidecars creates and starts new containers for each sidecar process.
 // Should only be used if the chain has previously been started with .Start.
 func (c *CosmosChain) StartAllSidecars(ctx context.Context) error {
@@ -0,0 +1,76 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	gogogrpc "github.com/cosmos/gogoproto/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// GRPCQueryOptions selects the node and the height of a query made with GRPCQuery.
+type GRPCQueryOptions struct {
+	// Node to query. If nil, the chain's default node is queried.
+	Node *ChainNode
+
+	// Height at which the state is queried. If zero, the latest state is queried.
+	// The node must not have pruned the state at that height.
+	Height int64
+}
+
+// GRPCConn returns a new connection to the node's gRPC server. The caller must close the connection.
+// Responses are decoded with the chain's EncodingConfig, so that interfaces such as public keys are unpacked.
+// This will not return a usable connection until the node has been started.
+func (tn *ChainNode) GRPCConn() (*grpc.ClientConn, error) {
+	cdc := codec.NewProtoCodec(tn.Chain.Config().EncodingConfig.InterfaceRegistry)
+	return grpc.Dial(
+		tn.hostGRPCPort,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(cdc.GRPCCodec())),
+	)
+}
+
+// GRPCConn returns a new connection to the gRPC server of the chain's default node. The caller must close the connection.
+// Responses are decoded with the chain's EncodingConfig, so that interfaces such as public keys are unpacked.
+func (c *CosmosChain) GRPCConn() (*grpc.ClientConn, error) {
+	return c.getFullNode().GRPCConn()
+}
+
+// GRPCQuery calls a method of the query client of any module of the chain, with a new connection to the selected node.
+// newClient is the module's query client constructor, as generated by gogoproto,
+// and method is the query method as a method expression, for example:
+//
+//	res, err := cosmos.GRPCQuery(ctx, chain, banktypes.NewQueryClient, banktypes.QueryClient.Balance,
+//		&banktypes.QueryBalanceRequest{Address: addr, Denom: denom}, cosmos.GRPCQueryOptions{Height: 10})
+func GRPCQuery[Client, Req, Res any](
+	ctx context.Context,
+	chain *CosmosChain,
+	newClient func(gogogrpc.ClientConn) Client,
+	method func(Client, context.Context, Req, ...grpc.CallOption) (Res, error),
+	req Req,
+	opts GRPCQueryOptions,
+) (Res, error) {
+	var zero Res
+
+	node := opts.Node
+	if node == nil {
+		node = chain.getFullNode()
+	}
+
+	conn, err := node.GRPCConn()
+	if err != nil {
+		return zero, fmt.Errorf("failed to connect to node %s: %w", node.Name(), err)
+	}
+	defer conn.Close()
+
+	if opts.Height > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strconv.FormatInt(opts.Height, 10))
+	}
+
+	return method(newClient(conn), ctx, req)
+}
@@ -0,0 +1,295 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	authTx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+)
+
+// BroadcastMode determines when a transaction broadcast by a Signer is considered done.
+type BroadcastMode int
+
+const (
+	// BroadcastModeCommit waits for the transaction to be included in a block,
+	// and returns the transaction response with the result of its execution and its events.
+	BroadcastModeCommit BroadcastMode = iota
+
+	// BroadcastModeSync returns once the transaction passes CheckTx and enters the mempool.
+	// The transaction response only holds the hash and the result of CheckTx.
+	BroadcastModeSync
+)
+
+// TxOptions configures a transaction built by a Signer.
+type TxOptions struct {
+	// Gas limit of the transaction. If zero, the gas is estimated by simulating the transaction,
+	// multiplied by the chain's GasAdjustment.
+	Gas uint64
+
+	// Fees of the transaction. If empty, the fees are the gas limit times the chain's GasPrices.
+	Fees types.Coins
+
+	Memo string
+
+	// Mode of the broadcast, BroadcastModeCommit by default.
+	Mode BroadcastMode
+}
+
+// Signer builds, signs and broadcasts transactions on the host, with the key of a wallet,
+// instead of executing the chain binary in a node container.
+// Transactions may hold any set of messages registered in the chain's EncodingConfig.
+//
+// A Signer tracks the sequence of its account, so transactions from the same Signer
+// may be broadcast in the same block. It is safe for concurrent use.
+type Signer struct {
+	chain   *CosmosChain
+	wallet  ibc.Wallet
+	privKey cryptotypes.PrivKey
+
+	mu            sync.Mutex
+	accountNumber uint64
+	sequence      uint64
+	// sequenceLoaded is false until the account is queried, and after a failed broadcast.
+	sequenceLoaded bool
+}
+
+// NewSigner returns a Signer for the wallet, deriving its key from the wallet's mnemonic
+// with the chain's coin type.
+// The wallet must have been built with a mnemonic, e.g. by BuildRelayerWallet or GetAndFundTestUserWithMnemonic.
+func NewSigner(chain *CosmosChain, wallet ibc.Wallet) (*Signer, error) {
+	if wallet.Mnemonic() == "" {
+		return nil, fmt.Errorf("wallet %s has no mnemonic", wallet.KeyName())
+	}
+
+	coinType, err := strconv.ParseUint(chain.cfg.CoinType, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid coin type: %w", err)
+	}
+
+	derived, err := hd.Secp256k1.Derive()(wallet.Mnemonic(), "", hd.CreateHDPath(uint32(coinType), 0, 0).String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key of wallet %s: %w", wallet.KeyName(), err)
+	}
+	privKey := hd.Secp256k1.Generate()(derived)
+
+	if addr := types.AccAddress(privKey.PubKey().Address()); !addr.Equals(types.AccAddress(wallet.Address())) {
+		return nil, fmt.Errorf("key derived from the mnemonic of wallet %s has address %s, expected %s",
+			wallet.KeyName(), types.MustBech32ifyAddressBytes(chain.cfg.Bech32Prefix, addr), wallet.FormattedAddress())
+	}
+
+	return &Signer{
+		chain:   chain,
+		wallet:  wallet,
+		privKey: privKey,
+	}, nil
+}
+
+// Wallet returns the wallet signing the transactions.
+func (s *Signer) Wallet() ibc.Wallet {
+	return s.wallet
+}
+
+// Broadcast builds a transaction with the messages, signs it and broadcasts it to the chain through RPC.
+// If the transaction fails, the returned error wraps its result, and the transaction response is returned
+// if the transaction was broadcast.
+func (s *Signer) Broadcast(ctx context.Context, opts TxOptions, msgs ...types.Msg) (*types.TxResponse, error) {
+	if len(msgs) == 0 {
+		return nil, errors.New("no messages to broadcast")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	node := s.chain.getFullNode()
+
+	if !s.sequenceLoaded {
+		if err := s.loadAccount(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	txBytes, err := s.signTx(ctx, opts, msgs)
+	if err != nil {
+		s.sequenceLoaded = false
+		return nil, err
+	}
+
+	res, err := node.Client.BroadcastTxSync(ctx, txBytes)
+	if err != nil {
+		s.sequenceLoaded = false
+		return nil, fmt.Errorf("failed to broadcast transaction: %w", err)
+	}
+
+	resp := types.NewResponseFormatBroadcastTx(res)
+	if resp.Code != 0 {
+		s.sequenceLoaded = false
+		return resp, fmt.Errorf("transaction failed check with code %d: %s", resp.Code, resp.RawLog)
+	}
+	s.sequence++
+
+	if opts.Mode == BroadcastModeSync {
+		return resp, nil
+	}
+
+	return s.waitForTx(ctx, node.CliContext(), resp.TxHash)
+}
+
+// loadAccount queries the account number and the sequence of the signer's account.
+func (s *Signer) loadAccount(ctx context.Context) error {
+	conn, err := s.chain.GRPCConn()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	res, err := authtypes.NewQueryClient(conn).Account(ctx, &authtypes.QueryAccountRequest{Address: s.wallet.FormattedAddress()})
+	if err != nil {
+		return fmt.Errorf("failed to query account %s: %w", s.wallet.FormattedAddress(), err)
+	}
+
+	var account types.AccountI
+	if err := s.chain.cfg.EncodingConfig.InterfaceRegistry.UnpackAny(res.Account, &account); err != nil {
+		return fmt.Errorf("failed to decode account %s: %w", s.wallet.FormattedAddress(), err)
+	}
+
+	s.accountNumber, s.sequence, s.sequenceLoaded = account.GetAccountNumber(), account.GetSequence(), true
+	return nil
+}
+
+// signTx builds and signs a transaction with the messages, estimating the gas and fees if they are not set,
+// and returns the encoded transaction.
+func (s *Signer) signTx(ctx context.Context, opts TxOptions, msgs []types.Msg) ([]byte, error) {
+	txConfig := s.chain.cfg.EncodingConfig.TxConfig
+	signMode := signing.SignMode_SIGN_MODE_DIRECT
+
+	builder := txConfig.NewTxBuilder()
+	if err := builder.SetMsgs(msgs...); err != nil {
+		return nil, fmt.Errorf("failed to set messages: %w", err)
+	}
+	builder.SetMemo(opts.Memo)
+
+	// An empty signature holds the public key and the sequence, for the simulation and for the sign bytes.
+	if err := builder.SetSignatures(signing.SignatureV2{
+		PubKey:   s.privKey.PubKey(),
+		Data:     &signing.SingleSignatureData{SignMode: signMode},
+		Sequence: s.sequence,
+	}); err != nil {
+		return nil, err
+	}
+
+	gas := opts.Gas
+	if gas == 0 {
+		simulated, err := s.simulate(ctx, builder)
+		if err != nil {
+			return nil, err
+		}
+		gasAdjustment := s.chain.cfg.GasAdjustment
+		if gasAdjustment == 0 {
+			gasAdjustment = 1
+		}
+		gas = uint64(float64(simulated) * gasAdjustment)
+	}
+	builder.SetGasLimit(gas)
+
+	fees := opts.Fees
+	if fees.Empty() {
+		var err error
+		fees, err = s.fees(gas)
+		if err != nil {
+			return nil, err
+		}
+	}
+	builder.SetFeeAmount(fees)
+
+	signerData := authsigning.SignerData{
+		Address:       s.wallet.FormattedAddress(),
+		ChainID:       s.chain.cfg.ChainID,
+		AccountNumber: s.accountNumber,
+		Sequence:      s.sequence,
+		PubKey:        s.privKey.PubKey(),
+	}
+	sig, err := tx.SignWithPrivKey(ctx, signMode, signerData, builder, s.privKey, txConfig, s.sequence)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	if err := builder.SetSignatures(sig); err != nil {
+		return nil, err
+	}
+
+	return txConfig.TxEncoder()(builder.GetTx())
+}
+
+// simulate returns the gas used by the transaction, which is not signed yet.
+func (s *Signer) simulate(ctx context.Context, builder client.TxBuilder) (uint64, error) {
+	txBytes, err := s.chain.cfg.EncodingConfig.TxConfig.TxEncoder()(builder.GetTx())
+	if err != nil {
+		return 0, err
+	}
+
+	conn, err := s.chain.GRPCConn()
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	res, err := txtypes.NewServiceClient(conn).Simulate(ctx, &txtypes.SimulateRequest{TxBytes: txBytes})
+	if err != nil {
+		return 0, fmt.Errorf("failed to simulate transaction: %w", err)
+	}
+
+	return res.GasInfo.GasUsed, nil
+}
+
+// fees returns the fees for the gas limit at the chain's gas prices.
+func (s *Signer) fees(gas uint64) (types.Coins, error) {
+	gasPrices, err := types.ParseDecCoins(s.chain.cfg.GasPrices)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas prices %q: %w", s.chain.cfg.GasPrices, err)
+	}
+
+	var fees types.Coins
+	for _, gasPrice := range gasPrices {
+		amount := gasPrice.Amount.MulInt(sdkmath.NewIntFromUint64(gas)).Ceil().TruncateInt()
+		fees = fees.Add(types.NewCoin(gasPrice.Denom, amount))
+	}
+	return fees, nil
+}
+
+// waitForTx waits up to 10 blocks for the transaction to be included in a block and returns its response.
+func (s *Signer) waitForTx(ctx context.Context, clientCtx client.Context, txHash string) (*types.TxResponse, error) {
+	var resp *types.TxResponse
+	err := testutil.WaitForBlocksUtil(10, func(int) error {
+		var err error
+		resp, err = authTx.QueryTx(clientCtx, txHash)
+		if err == nil {
+			return nil
+		}
+		if waitErr := testutil.WaitForBlocks(ctx, 1, s.chain); waitErr != nil {
+			return waitErr
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find transaction %s: %w", txHash, err)
+	}
+
+	if resp.Code != 0 {
+		return resp, fmt.Errorf("transaction failed with code %d: %s", resp.Code, resp.RawLog)
+	}
+	return resp, nil
+}
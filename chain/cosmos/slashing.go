@@ -0,0 +1,204 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+	"go.uber.org/zap"
+)
+
+// ValidatorConsensusAddress retrieves the consensus address of the node in bech32 format,
+// from its priv_validator_key.json.
+func (tn *ChainNode) ValidatorConsensusAddress(ctx context.Context) (string, error) {
+	bz, err := tn.ReadFile(ctx, "config/priv_validator_key.json")
+	if err != nil {
+		return "", err
+	}
+
+	var key PrivValidatorKeyFile
+	if err := json.Unmarshal(bz, &key); err != nil {
+		return "", fmt.Errorf("unmarshaling priv_validator_key.json: %w", err)
+	}
+
+	addr, err := hex.DecodeString(key.Address)
+	if err != nil {
+		return "", fmt.Errorf("decoding validator address %q: %w", key.Address, err)
+	}
+
+	return types.Bech32ifyAddressBytes(tn.Chain.Config().Bech32Prefix+"valcons", addr)
+}
+
+// Unjail submits an unjail transaction for the node's validator.
+// The downtime jail duration of the chain must have elapsed since the validator was jailed.
+func (tn *ChainNode) Unjail(ctx context.Context) error {
+	_, err := tn.ExecTx(ctx, valKey, "slashing", "unjail", "--gas", "auto")
+	return err
+}
+
+// ForceDowntime stops the node of the validator while the rest of the network produces the given number of blocks,
+// then starts it again.
+// The remaining validators must hold enough voting power for the chain to keep producing blocks.
+// Whether the validator is jailed for the missed blocks depends on the slashing params of the chain.
+func (c *CosmosChain) ForceDowntime(ctx context.Context, val *ChainNode, blocks int) error {
+	var live *ChainNode
+	for _, n := range c.Nodes() {
+		if n != val {
+			live = n
+			break
+		}
+	}
+	if live == nil {
+		return fmt.Errorf("chain %s has no node other than %s to produce blocks", c.cfg.ChainID, val.Name())
+	}
+
+	if err := val.StopContainer(ctx); err != nil {
+		return err
+	}
+	if err := val.RemoveContainer(ctx); err != nil {
+		return err
+	}
+
+	if err := testutil.WaitForBlocks(ctx, blocks, live); err != nil {
+		return fmt.Errorf("failed to wait for blocks while %s is down: %w", val.Name(), err)
+	}
+
+	if err := val.CreateNodeContainer(ctx); err != nil {
+		return err
+	}
+	return val.StartContainer(ctx)
+}
+
+// DoubleSign makes the validator double sign, by running a second node with a copy of its priv_validator_key.json
+// until the evidence of the conflicting votes is committed and the validator is tombstoned, waiting up to maxBlocks blocks.
+// The second node is then removed from the network.
+// The validator must be part of the active set; how much it is slashed depends on the slashing params of the chain.
+func (c *CosmosChain) DoubleSign(ctx context.Context, val *ChainNode, maxBlocks int) error {
+	consAddr, err := val.ValidatorConsensusAddress(ctx)
+	if err != nil {
+		return err
+	}
+
+	key, err := val.ReadFile(ctx, "config/priv_validator_key.json")
+	if err != nil {
+		return err
+	}
+
+	// Sync a new full node, then restart it with the key of the validator.
+	if err := c.AddFullNodes(ctx, nil, 1); err != nil {
+		return fmt.Errorf("failed to add double signing node: %w", err)
+	}
+	c.nodesMu.Lock()
+	double := c.FullNodes[len(c.FullNodes)-1]
+	c.nodesMu.Unlock()
+
+	defer func() {
+		c.nodesMu.Lock()
+		for i, n := range c.FullNodes {
+			if n == double {
+				c.FullNodes = append(c.FullNodes[:i:i], c.FullNodes[i+1:]...)
+				c.numFullNodes--
+				break
+			}
+		}
+		c.nodesMu.Unlock()
+
+		if err := double.StopContainer(ctx); err != nil {
+			c.log.Info("Failed to stop double signing node", zap.String("node", double.Name()), zap.Error(err))
+		}
+		_ = double.RemoveContainer(ctx)
+	}()
+
+	if err := double.StopContainer(ctx); err != nil {
+		return err
+	}
+	if err := double.RemoveContainer(ctx); err != nil {
+		return err
+	}
+	if err := double.WriteFile(ctx, key, "config/priv_validator_key.json"); err != nil {
+		return fmt.Errorf("failed to copy priv_validator_key.json: %w", err)
+	}
+	if err := double.CreateNodeContainer(ctx); err != nil {
+		return err
+	}
+	if err := double.StartContainer(ctx); err != nil {
+		return err
+	}
+
+	return testutil.WaitForBlocksUtil(maxBlocks, func(int) error {
+		info, err := c.QuerySigningInfo(ctx, consAddr)
+		if err == nil && info.Tombstoned {
+			return nil
+		}
+		if waitErr := testutil.WaitForBlocks(ctx, 1, val); waitErr != nil {
+			return waitErr
+		}
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("validator %s is not tombstoned", consAddr)
+	})
+}
+
+// Unjail unjails the validator.
+// The downtime jail duration of the chain must have elapsed since the validator was jailed.
+func (c *CosmosChain) Unjail(ctx context.Context, val *ChainNode) error {
+	return val.Unjail(ctx)
+}
+
+// IsJailed returns whether the validator with the given operator address is jailed.
+func (c *CosmosChain) IsJailed(ctx context.Context, valoper string) (bool, error) {
+	validator, err := c.QueryValidator(ctx, valoper)
+	if err != nil {
+		return false, err
+	}
+
+	return validator.Jailed, nil
+}
+
+// QuerySigningInfo returns the signing info of the validator with the given consensus address,
+// including its missed blocks counter, jailing time and whether it is tombstoned.
+func (c *CosmosChain) QuerySigningInfo(ctx context.Context, consAddr string) (*slashingtypes.ValidatorSigningInfo, error) {
+	conn, err := c.GRPCConn()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := slashingtypes.NewQueryClient(conn).SigningInfo(ctx, &slashingtypes.QuerySigningInfoRequest{ConsAddress: consAddr})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query signing info of %s: %w", consAddr, err)
+	}
+
+	return &res.ValSigningInfo, nil
+}
+
+// QuerySlashes returns the slash events of the validator with the given operator address, up to the current height.
+func (c *CosmosChain) QuerySlashes(ctx context.Context, valoper string) ([]distrtypes.ValidatorSlashEvent, error) {
+	height, err := c.Height(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := c.GRPCConn()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := distrtypes.NewQueryClient(conn).ValidatorSlashes(ctx, &distrtypes.QueryValidatorSlashesRequest{
+		ValidatorAddress: valoper,
+		StartingHeight:   0,
+		EndingHeight:     height,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query slashes of %s: %w", valoper, err)
+	}
+
+	return res.Slashes, nil
+}
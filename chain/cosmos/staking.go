@@ -0,0 +1,253 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// Delegate delegates amount to the validator with the given operator address, signed by keyName.
+func (tn *ChainNode) Delegate(ctx context.Context, keyName string, validatorAddress string, amount types.Coin) error {
+	_, err := tn.ExecTx(ctx, keyName,
+		"staking", "delegate", validatorAddress,
+		fmt.Sprintf("%s%s", amount.Amount.String(), amount.Denom),
+		"--gas", "auto",
+	)
+	return err
+}
+
+// Unbond undelegates amount from the validator with the given operator address, signed by keyName.
+func (tn *ChainNode) Unbond(ctx context.Context, keyName string, validatorAddress string, amount types.Coin) error {
+	_, err := tn.ExecTx(ctx, keyName,
+		"staking", "unbond", validatorAddress,
+		fmt.Sprintf("%s%s", amount.Amount.String(), amount.Denom),
+		"--gas", "auto",
+	)
+	return err
+}
+
+// Redelegate moves amount delegated by keyName from the source validator to the destination validator.
+func (tn *ChainNode) Redelegate(ctx context.Context, keyName string, srcValidatorAddress, dstValidatorAddress string, amount types.Coin) error {
+	_, err := tn.ExecTx(ctx, keyName,
+		"staking", "redelegate", srcValidatorAddress, dstValidatorAddress,
+		fmt.Sprintf("%s%s", amount.Amount.String(), amount.Denom),
+		"--gas", "auto",
+	)
+	return err
+}
+
+// WithdrawRewards withdraws the rewards of the delegation of keyName to the validator with the given operator address.
+// If commission is true, the commission of the validator is withdrawn as well, which requires keyName to be its operator.
+func (tn *ChainNode) WithdrawRewards(ctx context.Context, keyName string, validatorAddress string, commission bool) error {
+	command := []string{"distribution", "withdraw-rewards", validatorAddress, "--gas", "auto"}
+	if commission {
+		command = append(command, "--commission")
+	}
+	_, err := tn.ExecTx(ctx, keyName, command...)
+	return err
+}
+
+// Delegate delegates amount to the validator with the given operator address, signed by keyName.
+func (c *CosmosChain) Delegate(ctx context.Context, keyName string, validatorAddress string, amount types.Coin) error {
+	return c.getFullNode().Delegate(ctx, keyName, validatorAddress, amount)
+}
+
+// Undelegate undelegates amount from the validator with the given operator address, signed by keyName.
+// The tokens are returned once the unbonding period of the chain has elapsed, see QueryUnbondingDelegation.
+func (c *CosmosChain) Undelegate(ctx context.Context, keyName string, validatorAddress string, amount types.Coin) error {
+	return c.getFullNode().Unbond(ctx, keyName, validatorAddress, amount)
+}
+
+// Redelegate moves amount delegated by keyName from the source validator to the destination validator.
+func (c *CosmosChain) Redelegate(ctx context.Context, keyName string, srcValidatorAddress, dstValidatorAddress string, amount types.Coin) error {
+	return c.getFullNode().Redelegate(ctx, keyName, srcValidatorAddress, dstValidatorAddress, amount)
+}
+
+// WithdrawRewards withdraws the rewards of the delegation of keyName to the validator with the given operator address.
+func (c *CosmosChain) WithdrawRewards(ctx context.Context, keyName string, validatorAddress string) error {
+	return c.getFullNode().WithdrawRewards(ctx, keyName, validatorAddress, false)
+}
+
+// WithdrawCommission withdraws the commission and the self-delegation rewards of the validator.
+func (c *CosmosChain) WithdrawCommission(ctx context.Context, val *ChainNode) error {
+	valoper, err := val.ValidatorOperatorAddress(ctx)
+	if err != nil {
+		return err
+	}
+	return val.WithdrawRewards(ctx, valKey, valoper, true)
+}
+
+// QueryValidator returns the validator with the given operator address.
+func (c *CosmosChain) QueryValidator(ctx context.Context, validatorAddress string) (*stakingtypes.Validator, error) {
+	conn, err := c.GRPCConn()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := stakingtypes.NewQueryClient(conn).Validator(ctx, &stakingtypes.QueryValidatorRequest{ValidatorAddr: validatorAddress})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query validator %s: %w", validatorAddress, err)
+	}
+
+	return &res.Validator, nil
+}
+
+// QueryValidators returns the validators with the given status, e.g. stakingtypes.BondStatusBonded,
+// or all validators if status is empty.
+func (c *CosmosChain) QueryValidators(ctx context.Context, status string) ([]stakingtypes.Validator, error) {
+	conn, err := c.GRPCConn()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	queryClient := stakingtypes.NewQueryClient(conn)
+
+	var validators []stakingtypes.Validator
+	var nextKey []byte
+	for {
+		res, err := queryClient.Validators(ctx, &stakingtypes.QueryValidatorsRequest{
+			Status:     status,
+			Pagination: &query.PageRequest{Key: nextKey},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query validators: %w", err)
+		}
+
+		validators = append(validators, res.Validators...)
+
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return validators, nil
+		}
+		nextKey = res.Pagination.NextKey
+	}
+}
+
+// QueryDelegation returns the delegation of the delegator to the validator with the given operator address.
+func (c *CosmosChain) QueryDelegation(ctx context.Context, delegatorAddress, validatorAddress string) (*stakingtypes.DelegationResponse, error) {
+	conn, err := c.GRPCConn()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := stakingtypes.NewQueryClient(conn).Delegation(ctx, &stakingtypes.QueryDelegationRequest{
+		DelegatorAddr: delegatorAddress,
+		ValidatorAddr: validatorAddress,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query delegation of %s to %s: %w", delegatorAddress, validatorAddress, err)
+	}
+
+	return res.DelegationResponse, nil
+}
+
+// QueryDelegations returns all delegations of the delegator.
+func (c *CosmosChain) QueryDelegations(ctx context.Context, delegatorAddress string) ([]stakingtypes.DelegationResponse, error) {
+	conn, err := c.GRPCConn()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	queryClient := stakingtypes.NewQueryClient(conn)
+
+	var delegations []stakingtypes.DelegationResponse
+	var nextKey []byte
+	for {
+		res, err := queryClient.DelegatorDelegations(ctx, &stakingtypes.QueryDelegatorDelegationsRequest{
+			DelegatorAddr: delegatorAddress,
+			Pagination:    &query.PageRequest{Key: nextKey},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query delegations of %s: %w", delegatorAddress, err)
+		}
+
+		delegations = append(delegations, res.DelegationResponses...)
+
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return delegations, nil
+		}
+		nextKey = res.Pagination.NextKey
+	}
+}
+
+// QueryUnbondingDelegation returns the unbonding entries of the delegator from the validator with the given operator address.
+func (c *CosmosChain) QueryUnbondingDelegation(ctx context.Context, delegatorAddress, validatorAddress string) (*stakingtypes.UnbondingDelegation, error) {
+	conn, err := c.GRPCConn()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := stakingtypes.NewQueryClient(conn).UnbondingDelegation(ctx, &stakingtypes.QueryUnbondingDelegationRequest{
+		DelegatorAddr: delegatorAddress,
+		ValidatorAddr: validatorAddress,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unbonding delegation of %s from %s: %w", delegatorAddress, validatorAddress, err)
+	}
+
+	return &res.Unbond, nil
+}
+
+// QueryDelegationRewards returns the rewards of the delegation of the delegator to the validator with the given operator address.
+func (c *CosmosChain) QueryDelegationRewards(ctx context.Context, delegatorAddress, validatorAddress string) (types.DecCoins, error) {
+	conn, err := c.GRPCConn()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := distrtypes.NewQueryClient(conn).DelegationRewards(ctx, &distrtypes.QueryDelegationRewardsRequest{
+		DelegatorAddress: delegatorAddress,
+		ValidatorAddress: validatorAddress,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rewards of %s from %s: %w", delegatorAddress, validatorAddress, err)
+	}
+
+	return res.Rewards, nil
+}
+
+// QueryOutstandingRewards returns the rewards of the validator with the given operator address
+// that are not withdrawn yet, for all its delegations including its commission.
+func (c *CosmosChain) QueryOutstandingRewards(ctx context.Context, validatorAddress string) (types.DecCoins, error) {
+	conn, err := c.GRPCConn()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := distrtypes.NewQueryClient(conn).ValidatorOutstandingRewards(ctx, &distrtypes.QueryValidatorOutstandingRewardsRequest{
+		ValidatorAddress: validatorAddress,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query outstanding rewards of %s: %w", validatorAddress, err)
+	}
+
+	return res.Rewards.Rewards, nil
+}
+
+// QueryCommission returns the commission of the validator with the given operator address that is not withdrawn yet.
+func (c *CosmosChain) QueryCommission(ctx context.Context, validatorAddress string) (types.DecCoins, error) {
+	conn, err := c.GRPCConn()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := distrtypes.NewQueryClient(conn).ValidatorCommission(ctx, &distrtypes.QueryValidatorCommissionRequest{
+		ValidatorAddress: validatorAddress,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query commission of %s: %w", validatorAddress, err)
+	}
+
+	return res.Commission.Commission, nil
+}
@@ -266,6 +266,10 @@ func (tn *TendermintNode) StopContainer(ctx context.Context) error {
 	return tn.containerLifecycle.StopContainer(ctx)
 }
 
+func (tn *TendermintNode) RemoveContainer(ctx context.Context) error {
+	return tn.containerLifecycle.RemoveContainer(ctx)
+}
+
 func (tn *TendermintNode) StartContainer(ctx context.Context) error {
 	if err := tn.containerLifecycle.StartContainer(ctx); err != nil {
 		return err
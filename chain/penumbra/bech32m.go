@@ -0,0 +1,45 @@
+package penumbra
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil/bech32"
+	cryptov1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/crypto/v1alpha1"
+)
+
+// addressPrefix is the human-readable part of a bech32m encoded Penumbra address.
+const addressPrefix = "penumbrav2t"
+
+// assetIDPrefix is the human-readable part of a bech32m encoded asset ID.
+const assetIDPrefix = "passet"
+
+// encodeBech32m encodes data as a bech32m string with the given human-readable part.
+// see: https://github.com/bitcoin/bips/blob/master/bip-0350.mediawiki
+//
+// Penumbra addresses are longer than the 90 character limit of BIP-173, which the bech32 package only enforces
+// when decoding.
+func encodeBech32m(hrp string, data []byte) (string, error) {
+	if hrp == "" {
+		return "", fmt.Errorf("bech32m human-readable part cannot be empty")
+	}
+
+	values, err := bech32.ConvertBits(data, 8, 5, true)
+	if err != nil {
+		return "", fmt.Errorf("converting data to 5-bit groups: %w", err)
+	}
+
+	return bech32.EncodeM(hrp, values)
+}
+
+// encodeAddress returns the bech32m representation of the inner bytes of a Penumbra address.
+func encodeAddress(inner []byte) (string, error) {
+	return encodeBech32m(addressPrefix, inner)
+}
+
+// encodeAssetID returns the bech32m representation of an asset ID.
+func encodeAssetID(assetID *cryptov1alpha1.AssetId) (string, error) {
+	if assetID.GetAltBech32M() != "" {
+		return assetID.GetAltBech32M(), nil
+	}
+	return encodeBech32m(assetIDPrefix, assetID.GetInner())
+}
@@ -0,0 +1,45 @@
+package penumbra
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/bech32"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeBech32m(t *testing.T) {
+	// Valid bech32m strings taken from the BIP-350 test vectors.
+	encoded, err := encodeBech32m("a", nil)
+	require.NoError(t, err)
+	require.Equal(t, "a1lqfn3a", encoded)
+
+	data, err := hex.DecodeString("ffbbcdeb38bdab49ca307b9ac5a928398a418820")
+	require.NoError(t, err)
+	encoded, err = encodeBech32m("abcdef", data)
+	require.NoError(t, err)
+	require.Equal(t, "abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx", encoded)
+
+	_, err = encodeBech32m("", data)
+	require.Error(t, err)
+}
+
+func TestEncodeAddress(t *testing.T) {
+	// Penumbra addresses are 80 bytes, which exceeds the BIP-173 length limit.
+	inner := make([]byte, 80)
+	for i := range inner {
+		inner[i] = byte(i)
+	}
+
+	encoded, err := encodeAddress(inner)
+	require.NoError(t, err)
+	require.Greater(t, len(encoded), 90)
+
+	hrp, values, err := bech32.DecodeNoLimit(encoded)
+	require.NoError(t, err)
+	require.Equal(t, addressPrefix, hrp)
+
+	decoded, err := bech32.ConvertBits(values, 5, 8, false)
+	require.NoError(t, err)
+	require.Equal(t, inner, decoded)
+}
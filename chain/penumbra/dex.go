@@ -0,0 +1,345 @@
+package penumbra
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+
+	"cosmossdk.io/math"
+	clientv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/client/v1alpha1"
+	cryptov1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/crypto/v1alpha1"
+	dexv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/dex/v1alpha1"
+	transactionv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/transaction/v1alpha1"
+	viewv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/view/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// NewLiquidityPosition returns an opened liquidity position for the trading pair of denom1 and denom2, quoting the
+// price p/q (the amount of denom2 received for q units of denom1 is p) with the given fee in basis points.
+// The position is funded with reserve1 units of denom1 and reserve2 units of denom2.
+//
+// Penumbra requires the assets of a trading pair to be ordered by asset ID, so the caller is responsible for passing
+// the denoms in the canonical order.
+func NewLiquidityPosition(denom1, denom2 string, feeBps uint32, p, q, reserve1, reserve2 math.Int) (*dexv1alpha1.Position, error) {
+	nonce := make([]byte, 32)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate position nonce: %w", err)
+	}
+
+	return &dexv1alpha1.Position{
+		Phi: &dexv1alpha1.TradingFunction{
+			Component: &dexv1alpha1.BareTradingFunction{
+				Fee: feeBps,
+				P:   newAmount(p),
+				Q:   newAmount(q),
+			},
+			Pair: &dexv1alpha1.TradingPair{
+				Asset_1: &cryptov1alpha1.AssetId{AltBaseDenom: denom1},
+				Asset_2: &cryptov1alpha1.AssetId{AltBaseDenom: denom2},
+			},
+		},
+		Nonce: nonce,
+		State: &dexv1alpha1.PositionState{State: dexv1alpha1.PositionState_POSITION_STATE_ENUM_OPENED},
+		Reserves: &dexv1alpha1.Reserves{
+			R1: newAmount(reserve1),
+			R2: newAmount(reserve2),
+		},
+	}, nil
+}
+
+// Swap submits a swap of amount units of denom into targetDenom. The swap outputs are claimable by the client's
+// default address once the batch containing the swap has been executed, see SwapClaim.
+// Returns the commitment to the swap, which identifies it when claiming.
+func (p *PenumbraClientNode) Swap(ctx context.Context, amount math.Int, denom, targetDenom string, opts ...TxOption) (*cryptov1alpha1.StateCommitment, error) {
+	channel, err := grpc.Dial(p.hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer channel.Close()
+
+	viewClient := viewv1alpha1.NewViewProtocolServiceClient(channel)
+
+	claimAddr, err := viewClient.AddressByIndex(ctx, &viewv1alpha1.AddressByIndexRequest{
+		AddressIndex: addressIndex(0, 0),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get claim address: %w", err)
+	}
+
+	tpr := &viewv1alpha1.TransactionPlannerRequest{
+		Swaps: []*viewv1alpha1.TransactionPlannerRequest_Swap{{
+			Value: &cryptov1alpha1.Value{
+				Amount:  newAmount(amount),
+				AssetId: &cryptov1alpha1.AssetId{AltBaseDenom: denom},
+			},
+			TargetAsset: &cryptov1alpha1.AssetId{AltBaseDenom: targetDenom},
+			// No fee is prepaid for the swap claim.
+			Fee:          &cryptov1alpha1.Fee{Amount: &cryptov1alpha1.Amount{}},
+			ClaimAddress: claimAddr.Address,
+		}},
+	}
+	applyTxOptions(tpr, opts)
+
+	tx, _, err := p.planAndBroadcast(ctx, channel, tpr)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, action := range tx.GetBody().GetActions() {
+		if commitment := action.GetSwap().GetBody().GetPayload().GetCommitment(); commitment != nil {
+			return commitment, nil
+		}
+	}
+
+	return nil, errors.New("broadcast transaction does not contain a swap")
+}
+
+// SwapClaim claims the outputs of a previously submitted swap, identified by its commitment.
+func (p *PenumbraClientNode) SwapClaim(ctx context.Context, swapCommitment *cryptov1alpha1.StateCommitment) error {
+	channel, err := grpc.Dial(p.hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer channel.Close()
+
+	tpr := &viewv1alpha1.TransactionPlannerRequest{
+		SwapClaims: []*viewv1alpha1.TransactionPlannerRequest_SwapClaim{{
+			SwapCommitment: swapCommitment,
+		}},
+	}
+
+	_, _, err = p.planAndBroadcast(ctx, channel, tpr)
+	return err
+}
+
+// UnclaimedSwaps returns all swaps submitted by the client that have not been claimed yet.
+func (p *PenumbraClientNode) UnclaimedSwaps(ctx context.Context) ([]*viewv1alpha1.SwapRecord, error) {
+	channel, err := grpc.Dial(p.hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer channel.Close()
+
+	viewClient := viewv1alpha1.NewViewProtocolServiceClient(channel)
+
+	stream, err := viewClient.UnclaimedSwaps(ctx, &viewv1alpha1.UnclaimedSwapsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	var swaps []*viewv1alpha1.SwapRecord
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			// A gRPC streaming response will return EOF when it's done.
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		swaps = append(swaps, resp.Swap)
+	}
+
+	return swaps, nil
+}
+
+// OpenPosition opens the liquidity position, funding its reserves from the client's account.
+// See NewLiquidityPosition for constructing a position.
+//
+// Returns the ID of the opened position: the owned position holding the nonce of the position opened by the
+// broadcast transaction, which is unique to the position.
+func (p *PenumbraClientNode) OpenPosition(ctx context.Context, position *dexv1alpha1.Position, opts ...TxOption) (*dexv1alpha1.PositionId, error) {
+	channel, err := grpc.Dial(p.hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer channel.Close()
+
+	tpr := &viewv1alpha1.TransactionPlannerRequest{
+		PositionOpens: []*viewv1alpha1.TransactionPlannerRequest_PositionOpen{{
+			Position: position,
+		}},
+	}
+	applyTxOptions(tpr, opts)
+
+	viewClient := viewv1alpha1.NewViewProtocolServiceClient(channel)
+	resp, err := viewClient.TransactionPlanner(ctx, tpr)
+	if err != nil {
+		return nil, err
+	}
+
+	opened, err := plannedPositionOpen(resp.Plan)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, _, err := p.authorizeAndBroadcast(ctx, channel, resp.Plan); err != nil {
+		return nil, err
+	}
+
+	ids, err := p.ownedPositionIDs(ctx, channel, dexv1alpha1.PositionState_POSITION_STATE_ENUM_OPENED, opened.GetPhi().GetPair())
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		owned, err := p.liquidityPosition(ctx, channel, id)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(owned.Nonce, opened.Nonce) {
+			return id, nil
+		}
+	}
+
+	return nil, fmt.Errorf("opened liquidity position with nonce %x not found", opened.Nonce)
+}
+
+// plannedPositionOpen returns the position opened by the transaction plan, which must open exactly one position.
+func plannedPositionOpen(plan *transactionv1alpha1.TransactionPlan) (*dexv1alpha1.Position, error) {
+	var positions []*dexv1alpha1.Position
+	for _, action := range plan.GetActions() {
+		if open := action.GetPositionOpen(); open != nil {
+			positions = append(positions, open.Position)
+		}
+	}
+	if len(positions) != 1 {
+		return nil, fmt.Errorf("expected the transaction plan to open 1 liquidity position, got %d", len(positions))
+	}
+	return positions[0], nil
+}
+
+// ClosePosition closes the liquidity position, so that it no longer provides liquidity.
+// The reserves of a closed position can be withdrawn with WithdrawPosition.
+func (p *PenumbraClientNode) ClosePosition(ctx context.Context, positionID *dexv1alpha1.PositionId, opts ...TxOption) error {
+	channel, err := grpc.Dial(p.hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer channel.Close()
+
+	tpr := &viewv1alpha1.TransactionPlannerRequest{
+		PositionCloses: []*viewv1alpha1.TransactionPlannerRequest_PositionClose{{
+			PositionId: positionID,
+		}},
+	}
+	applyTxOptions(tpr, opts)
+
+	_, _, err = p.planAndBroadcast(ctx, channel, tpr)
+	return err
+}
+
+// WithdrawPosition withdraws the current reserves of a closed liquidity position into the client's account.
+func (p *PenumbraClientNode) WithdrawPosition(ctx context.Context, positionID *dexv1alpha1.PositionId, opts ...TxOption) error {
+	channel, err := grpc.Dial(p.hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer channel.Close()
+
+	position, err := p.liquidityPosition(ctx, channel, positionID)
+	if err != nil {
+		return err
+	}
+
+	tpr := &viewv1alpha1.TransactionPlannerRequest{
+		PositionWithdraws: []*viewv1alpha1.TransactionPlannerRequest_PositionWithdraw{{
+			PositionId:  positionID,
+			Reserves:    position.Reserves,
+			TradingPair: position.GetPhi().GetPair(),
+		}},
+	}
+	applyTxOptions(tpr, opts)
+
+	_, _, err = p.planAndBroadcast(ctx, channel, tpr)
+	return err
+}
+
+// LiquidityPosition returns the current on-chain state of the liquidity position.
+func (p *PenumbraClientNode) LiquidityPosition(ctx context.Context, positionID *dexv1alpha1.PositionId) (*dexv1alpha1.Position, error) {
+	channel, err := grpc.Dial(p.hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer channel.Close()
+
+	return p.liquidityPosition(ctx, channel, positionID)
+}
+
+// OwnedPositionIDs returns the IDs of the liquidity positions owned by the client in the given state.
+// If pair is non-nil, only positions for that trading pair are returned.
+// An unspecified state returns positions in any state.
+func (p *PenumbraClientNode) OwnedPositionIDs(
+	ctx context.Context,
+	state dexv1alpha1.PositionState_PositionStateEnum,
+	pair *dexv1alpha1.TradingPair,
+) ([]*dexv1alpha1.PositionId, error) {
+	channel, err := grpc.Dial(p.hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer channel.Close()
+
+	return p.ownedPositionIDs(ctx, channel, state, pair)
+}
+
+func (p *PenumbraClientNode) ownedPositionIDs(
+	ctx context.Context,
+	channel *grpc.ClientConn,
+	state dexv1alpha1.PositionState_PositionStateEnum,
+	pair *dexv1alpha1.TradingPair,
+) ([]*dexv1alpha1.PositionId, error) {
+	viewClient := viewv1alpha1.NewViewProtocolServiceClient(channel)
+
+	req := &viewv1alpha1.OwnedPositionIdsRequest{
+		TradingPair: pair,
+	}
+	if state != dexv1alpha1.PositionState_POSITION_STATE_ENUM_UNSPECIFIED {
+		req.PositionState = &dexv1alpha1.PositionState{State: state}
+	}
+
+	stream, err := viewClient.OwnedPositionIds(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []*dexv1alpha1.PositionId
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			// A gRPC streaming response will return EOF when it's done.
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		ids = append(ids, resp.PositionId)
+	}
+
+	return ids, nil
+}
+
+func (p *PenumbraClientNode) liquidityPosition(
+	ctx context.Context,
+	channel *grpc.ClientConn,
+	positionID *dexv1alpha1.PositionId,
+) (*dexv1alpha1.Position, error) {
+	queryClient := clientv1alpha1.NewSpecificQueryServiceClient(channel)
+	req := &clientv1alpha1.LiquidityPositionByIdRequest{
+		ChainId:    p.Chain.Config().ChainID,
+		PositionId: positionID,
+	}
+
+	resp, err := queryClient.LiquidityPositionById(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, fmt.Errorf("liquidity position %x not found", positionID.GetInner())
+	}
+
+	return resp.Data, nil
+}
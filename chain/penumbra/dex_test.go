@@ -0,0 +1,49 @@
+package penumbra
+
+import (
+	"testing"
+
+	"cosmossdk.io/math"
+	dexv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/dex/v1alpha1"
+	transactionv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/transaction/v1alpha1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLiquidityPosition(t *testing.T) {
+	reserve := math.NewIntWithDecimal(1, 20)
+
+	position, err := NewLiquidityPosition("gm", "gn", 30, math.NewInt(2), math.NewInt(1), math.ZeroInt(), reserve)
+	require.NoError(t, err)
+
+	require.Equal(t, dexv1alpha1.PositionState_POSITION_STATE_ENUM_OPENED, position.State.State)
+	require.Equal(t, uint32(30), position.Phi.Component.Fee)
+	require.Equal(t, "gm", position.Phi.Pair.Asset_1.AltBaseDenom)
+	require.Equal(t, "gn", position.Phi.Pair.Asset_2.AltBaseDenom)
+	require.Equal(t, uint64(2), position.Phi.Component.P.Lo)
+	require.Equal(t, reserve, translateHiAndLo(position.Reserves.R2.Hi, position.Reserves.R2.Lo))
+	require.Len(t, position.Nonce, 32)
+
+	other, err := NewLiquidityPosition("gm", "gn", 30, math.NewInt(2), math.NewInt(1), math.ZeroInt(), reserve)
+	require.NoError(t, err)
+	require.NotEqual(t, position.Nonce, other.Nonce)
+}
+
+func TestPlannedPositionOpen(t *testing.T) {
+	position, err := NewLiquidityPosition("gm", "gn", 30, math.NewInt(2), math.NewInt(1), math.ZeroInt(), math.NewInt(100))
+	require.NoError(t, err)
+
+	spend := &transactionv1alpha1.ActionPlan{Action: &transactionv1alpha1.ActionPlan_Spend{Spend: &transactionv1alpha1.SpendPlan{}}}
+	open := &transactionv1alpha1.ActionPlan{Action: &transactionv1alpha1.ActionPlan_PositionOpen{
+		PositionOpen: &dexv1alpha1.PositionOpen{Position: position},
+	}}
+
+	opened, err := plannedPositionOpen(&transactionv1alpha1.TransactionPlan{Actions: []*transactionv1alpha1.ActionPlan{spend, open}})
+	require.NoError(t, err)
+	require.Equal(t, position.Nonce, opened.Nonce)
+
+	_, err = plannedPositionOpen(&transactionv1alpha1.TransactionPlan{Actions: []*transactionv1alpha1.ActionPlan{spend}})
+	require.Error(t, err)
+
+	_, err = plannedPositionOpen(&transactionv1alpha1.TransactionPlan{Actions: []*transactionv1alpha1.ActionPlan{open, open}})
+	require.Error(t, err)
+}
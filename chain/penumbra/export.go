@@ -0,0 +1,275 @@
+package penumbra
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	clientv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/client/v1alpha1"
+	"github.com/strangelove-ventures/interchaintest/v8/internal/dockerutil"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// PenumbraStateExport describes an export of pd's state storage made by PenumbraChain.ExportPdState.
+type PenumbraStateExport struct {
+	ChainID string `json:"chain_id"`
+	// Height is the last block committed to the exported state.
+	Height uint64 `json:"height"`
+	// AppHash is the hex encoded app hash of the exported state.
+	AppHash string `json:"app_hash"`
+	// Volume is the docker volume holding the export and Path is the location of the export within the volume.
+	Volume string `json:"volume"`
+	Path   string `json:"path"`
+}
+
+// ExportState exports the state of the full node's pd instance once the chain has reached height, see ExportPdState.
+// Implements Chain interface: like CosmosChain.ExportState it returns a genesis file, which is the CometBFT genesis
+// continuing the chain from the exported state. The exported pd state itself stays in the full node's volume,
+// use ExportPdState and StartFromExport to start a chain from it.
+func (c *PenumbraChain) ExportState(ctx context.Context, height int64) (string, error) {
+	export, err := c.ExportPdState(ctx, height)
+	if err != nil {
+		return "", err
+	}
+
+	appHash, err := hex.DecodeString(export.AppHash)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode app hash: %w", err)
+	}
+
+	genesis, err := c.getFullNode().TendermintNode.GenesisFileContent(ctx)
+	if err != nil {
+		return "", err
+	}
+	genesis, err = forkGenesis(genesis, export.Height, appHash)
+	if err != nil {
+		return "", err
+	}
+
+	return string(genesis), nil
+}
+
+// ExportPdState exports the state of the full node's pd instance once the chain has reached height.
+// pd can only export its latest committed state, so the full node is halted as soon as height is reached,
+// its state is exported and the node is restarted, also when the export fails. The exported height may therefore
+// be slightly higher than requested and is recorded in the returned export, which can be passed to StartFromExport.
+func (c *PenumbraChain) ExportPdState(ctx context.Context, height int64) (export *PenumbraStateExport, err error) {
+	fn := c.getFullNode()
+
+	if err := c.waitForHeight(ctx, uint64(height)); err != nil {
+		return nil, err
+	}
+
+	// Stop CometBFT first, so that pd does not commit any further blocks while its last commit is inspected.
+	if err := fn.TendermintNode.StopContainer(ctx); err != nil {
+		return nil, fmt.Errorf("failed to stop tendermint: %w", err)
+	}
+
+	pdStopped := false
+	defer func() {
+		if pdStopped {
+			if startErr := fn.PenumbraAppNode.StartContainer(ctx); startErr != nil {
+				multierr.AppendInto(&err, fmt.Errorf("failed to restart pd: %w", startErr))
+			}
+		}
+		if startErr := fn.TendermintNode.StartContainer(ctx); startErr != nil {
+			multierr.AppendInto(&err, fmt.Errorf("failed to restart tendermint: %w", startErr))
+		}
+		if err != nil {
+			export = nil
+		}
+	}()
+
+	info, err := pdInfo(ctx, fn.PenumbraAppNode.hostGRPCPort)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fn.PenumbraAppNode.StopContainer(ctx); err != nil {
+		return nil, fmt.Errorf("failed to stop pd: %w", err)
+	}
+	pdStopped = true
+
+	relPath := path.Join("exports", strconv.FormatUint(info.LastBlockHeight, 10))
+	if err := fn.PenumbraAppNode.ExportState(ctx, relPath); err != nil {
+		return nil, err
+	}
+
+	return &PenumbraStateExport{
+		ChainID: c.cfg.ChainID,
+		Height:  info.LastBlockHeight,
+		AppHash: strings.ToUpper(hex.EncodeToString(info.LastBlockAppHash)),
+		Volume:  fn.PenumbraAppNode.VolumeName,
+		Path:    relPath,
+	}, nil
+}
+
+// StartFromExport starts the chain from state exported from source with ExportPdState, as in a hard fork.
+// The chain must be initialized and have the same number of validators and full nodes as source.
+// The validator identities and keys of source are reused, since the exported state determines the validator set.
+// Blocks continue from the exported height, using the genesis of source with the exported app hash.
+func (c *PenumbraChain) StartFromExport(testName string, ctx context.Context, source *PenumbraChain, stateExport *PenumbraStateExport) error {
+	if c.numValidators != source.numValidators || len(c.PenumbraNodes) != len(source.PenumbraNodes) {
+		return fmt.Errorf(
+			"chain has %d validators and %d nodes, but the source chain has %d validators and %d nodes",
+			c.numValidators, len(c.PenumbraNodes), source.numValidators, len(source.PenumbraNodes),
+		)
+	}
+
+	appHash, err := hex.DecodeString(stateExport.AppHash)
+	if err != nil {
+		return fmt.Errorf("failed to decode app hash: %w", err)
+	}
+
+	genesis, err := source.getFullNode().TendermintNode.GenesisFileContent(ctx)
+	if err != nil {
+		return err
+	}
+	genesis, err = forkGenesis(genesis, stateExport.Height, appHash)
+	if err != nil {
+		return err
+	}
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	for i, n := range c.PenumbraNodes {
+		i, n := i, n
+		src := source.PenumbraNodes[i]
+		eg.Go(func() error {
+			if i < c.numValidators {
+				if err := n.TendermintNode.InitValidatorFiles(egCtx); err != nil {
+					return fmt.Errorf("error initializing validator files: %w", err)
+				}
+
+				fr := dockerutil.NewFileRetriever(c.log, src.TendermintNode.DockerClient, src.TendermintNode.TestName)
+				pk, err := fr.SingleFileContent(egCtx, src.TendermintNode.VolumeName, "config/priv_validator_key.json")
+				if err != nil {
+					return fmt.Errorf("error getting source validator private key content: %w", err)
+				}
+
+				fw := dockerutil.NewFileWriter(c.log, n.TendermintNode.DockerClient, n.TendermintNode.TestName)
+				if err := fw.WriteFile(egCtx, n.TendermintNode.VolumeName, "config/priv_validator_key.json", pk); err != nil {
+					return fmt.Errorf("overwriting priv_validator_key.json: %w", err)
+				}
+			} else if err := n.TendermintNode.InitFullNodeFiles(egCtx); err != nil {
+				return fmt.Errorf("error initializing full node files: %w", err)
+			}
+
+			if err := n.PenumbraAppNode.copyFromVolume(egCtx, src.PenumbraAppNode.VolumeName, "keys", "keys"); err != nil {
+				return fmt.Errorf("error copying keys from source node: %w", err)
+			}
+
+			return n.PenumbraAppNode.copyFromVolume(egCtx, stateExport.Volume, stateExport.Path, ".")
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	// start distributes the genesis file generated by pd on the first validator to all nodes.
+	firstVal := c.PenumbraNodes[0]
+	fw := dockerutil.NewFileWriter(c.log, firstVal.PenumbraAppNode.DockerClient, firstVal.PenumbraAppNode.TestName)
+	if err := fw.WriteFile(ctx, firstVal.PenumbraAppNode.VolumeName, ".penumbra/testnet_data/node0/tendermint/config/genesis.json", genesis); err != nil {
+		return fmt.Errorf("error writing genesis file: %w", err)
+	}
+
+	c.log.Info("Starting chain from exported state",
+		zap.String("source_chain", stateExport.ChainID),
+		zap.Uint64("height", stateExport.Height),
+	)
+
+	return c.start(ctx)
+}
+
+// waitForHeight blocks until the chain has reached height.
+func (c *PenumbraChain) waitForHeight(ctx context.Context, height uint64) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		h, err := c.Height(ctx)
+		if err == nil && h >= height {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("chain did not reach height %d: %w", height, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// ExportState exports pd's state storage to relPath within the node's volume. pd must be stopped.
+func (p *PenumbraAppNode) ExportState(ctx context.Context, relPath string) error {
+	cmd := []string{
+		"pd", "export",
+		"--home", p.HomeDir(),
+		"--export-path", path.Join(p.HomeDir(), relPath),
+	}
+	if _, _, err := p.Exec(ctx, cmd, nil); err != nil {
+		return fmt.Errorf("failed to export pd state: %w", err)
+	}
+	return nil
+}
+
+// copyFromVolume copies the contents of srcRelPath within srcVolume into dstRelPath within the node's volume.
+func (p *PenumbraAppNode) copyFromVolume(ctx context.Context, srcVolume, srcRelPath, dstRelPath string) error {
+	const srcMount = "/mnt/src"
+
+	job := dockerutil.NewImage(p.log, p.DockerClient, p.NetworkID, p.TestName, p.Image.Repository, p.Image.Version)
+	opts := dockerutil.ContainerOptions{
+		Binds: append(p.Bind(), srcVolume+":"+srcMount),
+		User:  p.Image.UidGid,
+	}
+
+	dst := path.Join(p.HomeDir(), dstRelPath)
+	cmd := []string{"sh", "-c", `mkdir -p "$1" && cp -a "$0"/. "$1"`, path.Join(srcMount, srcRelPath), dst}
+
+	res := job.Run(ctx, cmd, opts)
+	return res.Err
+}
+
+// pdInfo queries the ABCI info of the pd instance at grpcAddr.
+func pdInfo(ctx context.Context, grpcAddr string) (*clientv1alpha1.InfoResponse, error) {
+	channel, err := grpc.Dial(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer channel.Close()
+
+	queryClient := clientv1alpha1.NewObliviousQueryServiceClient(channel)
+	info, err := queryClient.Info(ctx, &clientv1alpha1.InfoRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pd info: %w", err)
+	}
+
+	return info, nil
+}
+
+// forkGenesis returns the CometBFT genesis continuing the chain from state committed at height with the given
+// app hash. CometBFT skips InitChain when the application has already committed state, so the exported state is
+// used as is and the first block produced is height + 1.
+func forkGenesis(genesis []byte, height uint64, appHash []byte) ([]byte, error) {
+	var g map[string]any
+	if err := json.Unmarshal(genesis, &g); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+	}
+
+	g["initial_height"] = strconv.FormatUint(height+1, 10)
+	g["app_hash"] = strings.ToUpper(hex.EncodeToString(appHash))
+
+	bz, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal genesis file: %w", err)
+	}
+	return bz, nil
+}
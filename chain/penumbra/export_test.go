@@ -0,0 +1,32 @@
+package penumbra
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestForkGenesis(t *testing.T) {
+	genesis := []byte(`{
+  "genesis_time": "2023-01-01T00:00:00Z",
+  "chain_id": "penumbra-1",
+  "initial_height": "0",
+  "app_hash": "",
+  "app_state": {"chain_params": {"chain_id": "penumbra-1"}}
+}`)
+
+	forked, err := forkGenesis(genesis, 41, []byte{0xab, 0xcd})
+	require.NoError(t, err)
+
+	var g map[string]any
+	require.NoError(t, json.Unmarshal(forked, &g))
+
+	require.Equal(t, "42", g["initial_height"])
+	require.Equal(t, "ABCD", g["app_hash"])
+	require.Equal(t, "penumbra-1", g["chain_id"])
+	require.Equal(t, map[string]any{"chain_params": map[string]any{"chain_id": "penumbra-1"}}, g["app_state"])
+
+	_, err = forkGenesis([]byte("not json"), 41, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,60 @@
+package penumbra
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"cosmossdk.io/math"
+	"github.com/cosmos/gogoproto/proto"
+	cryptov1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/crypto/v1alpha1"
+	transactionv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/transaction/v1alpha1"
+	viewv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/view/v1alpha1"
+)
+
+// TxOption configures a transaction planned by a pclientd instance.
+type TxOption func(tpr *viewv1alpha1.TransactionPlannerRequest)
+
+// WithFee pays exactly amount of the staking token as fee.
+// The transaction planner of this protocol version takes an explicit fee and pays none by default.
+func WithFee(amount math.Int) TxOption {
+	return func(tpr *viewv1alpha1.TransactionPlannerRequest) {
+		tpr.Fee = &cryptov1alpha1.Fee{Amount: newAmount(amount)}
+	}
+}
+
+func applyTxOptions(tpr *viewv1alpha1.TransactionPlannerRequest, opts []TxOption) {
+	for _, opt := range opts {
+		opt(tpr)
+	}
+}
+
+// feeAmount returns the amount of the fee, or zero if no fee is paid.
+func feeAmount(fee *cryptov1alpha1.Fee) math.Int {
+	amount := fee.GetAmount()
+	if amount == nil {
+		return math.ZeroInt()
+	}
+	return translateHiAndLo(amount.GetHi(), amount.GetLo())
+}
+
+// TxFee returns the fee paid by the transaction with the given hex encoded hash,
+// in the base denom of the staking token.
+func (c *PenumbraChain) TxFee(ctx context.Context, txHash string) (math.Int, error) {
+	hash, err := hex.DecodeString(txHash)
+	if err != nil {
+		return math.Int{}, fmt.Errorf("invalid transaction hash %s: %w", txHash, err)
+	}
+
+	txResp, err := c.getFullNode().TendermintNode.Client.Tx(ctx, hash, false)
+	if err != nil {
+		return math.Int{}, fmt.Errorf("failed to get transaction %s: %w", txHash, err)
+	}
+
+	var tx transactionv1alpha1.Transaction
+	if err := proto.Unmarshal(txResp.Tx, &tx); err != nil {
+		return math.Int{}, fmt.Errorf("failed to decode transaction %s: %w", txHash, err)
+	}
+
+	return feeAmount(tx.GetBody().GetFee()), nil
+}
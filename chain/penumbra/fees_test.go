@@ -0,0 +1,39 @@
+package penumbra
+
+import (
+	"testing"
+
+	"cosmossdk.io/math"
+	cryptov1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/crypto/v1alpha1"
+	viewv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/view/v1alpha1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxOptions(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		opts []TxOption
+		want math.Int
+	}{
+		{"no options", nil, math.ZeroInt()},
+		{"fee", []TxOption{WithFee(math.NewInt(1234))}, math.NewInt(1234)},
+		{"last fee wins", []TxOption{WithFee(math.NewInt(1234)), WithFee(math.NewInt(5))}, math.NewInt(5)},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tpr := &viewv1alpha1.TransactionPlannerRequest{}
+			applyTxOptions(tpr, tt.opts)
+			require.True(t, tt.want.Equal(feeAmount(tpr.Fee)), "got %s, want %s", feeAmount(tpr.Fee), tt.want)
+		})
+	}
+}
+
+func TestFeeAmount(t *testing.T) {
+	require.True(t, feeAmount(nil).IsZero())
+	require.True(t, feeAmount(&cryptov1alpha1.Fee{}).IsZero())
+
+	// Amounts are split into the high and low 64 bits.
+	fee := &cryptov1alpha1.Fee{Amount: &cryptov1alpha1.Amount{Lo: 7, Hi: 1}}
+	want := math.NewIntFromUint64(1).Mul(math.NewIntFromUint64(1 << 32)).Mul(math.NewIntFromUint64(1 << 32)).AddRaw(7)
+	require.True(t, want.Equal(feeAmount(fee)))
+}
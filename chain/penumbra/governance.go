@@ -0,0 +1,311 @@
+package penumbra
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"cosmossdk.io/math"
+	"github.com/BurntSushi/toml"
+	"github.com/cosmos/gogoproto/proto"
+	clientv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/client/v1alpha1"
+	governancev1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/governance/v1alpha1"
+	"github.com/strangelove-ventures/interchaintest/v8/internal/dockerutil"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// PenumbraProposalKind is the kind of a governance proposal, as understood by pcli.
+type PenumbraProposalKind string
+
+const (
+	ProposalKindSignaling       PenumbraProposalKind = "signaling"
+	ProposalKindEmergency       PenumbraProposalKind = "emergency"
+	ProposalKindParameterChange PenumbraProposalKind = "parameter_change"
+	ProposalKindDaoSpend        PenumbraProposalKind = "dao_spend"
+)
+
+// Proposal statuses returned by ProposalStatus.
+const (
+	ProposalStatusVoting    = "voting"
+	ProposalStatusWithdrawn = "withdrawn"
+	ProposalStatusPassed    = "passed"
+	ProposalStatusFailed    = "failed"
+	ProposalStatusSlashed   = "slashed"
+	ProposalStatusClaimed   = "claimed"
+)
+
+// PenumbraVote is a vote on a governance proposal, cast with DelegatorVote or ValidatorVote.
+type PenumbraVote = governancev1alpha1.Vote_Vote
+
+const (
+	VoteYes     PenumbraVote = governancev1alpha1.Vote_VOTE_YES
+	VoteNo      PenumbraVote = governancev1alpha1.Vote_VOTE_NO
+	VoteAbstain PenumbraVote = governancev1alpha1.Vote_VOTE_ABSTAIN
+)
+
+// voteArg returns the vote as understood by pcli.
+func voteArg(vote PenumbraVote) (string, error) {
+	switch vote {
+	case VoteYes:
+		return "yes", nil
+	case VoteNo:
+		return "no", nil
+	case VoteAbstain:
+		return "abstain", nil
+	default:
+		return "", fmt.Errorf("invalid vote %s", vote)
+	}
+}
+
+// Keys of the governance component state in pd, see the state_key module of penumbra's governance component.
+const (
+	proposalStateKeyPrefix = "governance/proposal/"
+	proposalStateKeySuffix = "/state"
+)
+
+// PenumbraProposal is a governance proposal in the TOML format read by pcli.
+// The payload fields differ per proposal kind, so the proposal is kept as a generic map.
+// Use ProposalTemplate to obtain a proposal populated with the next proposal ID and,
+// for parameter changes, the current chain parameters.
+type PenumbraProposal map[string]any
+
+// NewSignalingProposal returns a signaling proposal, optionally referencing a commit.
+func NewSignalingProposal(id uint64, title, description, commit string) PenumbraProposal {
+	p := newProposal(id, title, description, ProposalKindSignaling)
+	if commit != "" {
+		p["commit"] = commit
+	}
+	return p
+}
+
+// NewEmergencyProposal returns an emergency proposal, which halts the chain if haltChain is true and the proposal passes.
+func NewEmergencyProposal(id uint64, title, description string, haltChain bool) PenumbraProposal {
+	p := newProposal(id, title, description, ProposalKindEmergency)
+	p["halt_chain"] = haltChain
+	return p
+}
+
+// NewParameterChangeProposal returns a proposal changing the chain parameters from oldParameters to newParameters.
+// The parameters use the field names of the chain parameters in the pcli proposal template.
+func NewParameterChangeProposal(id uint64, title, description string, oldParameters, newParameters map[string]any) PenumbraProposal {
+	p := newProposal(id, title, description, ProposalKindParameterChange)
+	p["old_parameters"] = oldParameters
+	p["new_parameters"] = newParameters
+	return p
+}
+
+// NewDaoSpendProposal returns a community pool spend proposal, executing the encoded transaction plan if it passes.
+func NewDaoSpendProposal(id uint64, title, description string, transactionPlan []byte) PenumbraProposal {
+	p := newProposal(id, title, description, ProposalKindDaoSpend)
+	p["transaction_plan"] = base64.StdEncoding.EncodeToString(transactionPlan)
+	return p
+}
+
+func newProposal(id uint64, title, description string, kind PenumbraProposalKind) PenumbraProposal {
+	return PenumbraProposal{
+		"id":          id,
+		"title":       title,
+		"description": description,
+		"kind":        string(kind),
+	}
+}
+
+// ProposalStatus returns a short description of the proposal state, one of the ProposalStatus constants.
+// Finished proposals are described by their outcome.
+func ProposalStatus(state *governancev1alpha1.ProposalState) string {
+	switch {
+	case state.GetVoting() != nil:
+		return ProposalStatusVoting
+	case state.GetWithdrawn() != nil:
+		return ProposalStatusWithdrawn
+	case state.GetClaimed() != nil:
+		return ProposalStatusClaimed
+	case state.GetFinished() != nil:
+		outcome := state.GetFinished().GetOutcome()
+		switch {
+		case outcome.GetPassed() != nil:
+			return ProposalStatusPassed
+		case outcome.GetFailed() != nil:
+			return ProposalStatusFailed
+		case outcome.GetSlashed() != nil:
+			return ProposalStatusSlashed
+		}
+	}
+	return ""
+}
+
+// ProposalTemplate returns a template for a proposal of the given kind, generated by pcli for keyName.
+func (p *PenumbraAppNode) ProposalTemplate(ctx context.Context, keyName string, kind PenumbraProposalKind) (PenumbraProposal, error) {
+	relPath := fmt.Sprintf("proposal_%s.toml", keyName)
+	keyPath := filepath.Join(p.HomeDir(), "keys", keyName)
+	pdUrl := fmt.Sprintf("http://%s:8080", p.HostName())
+	cmd := []string{
+		"pcli",
+		"-d", keyPath,
+		"-n", pdUrl,
+		"tx", "proposal", "template",
+		// pcli takes the kind in kebab case, while the proposal file uses snake case.
+		"--kind", strings.ReplaceAll(string(kind), "_", "-"),
+		"--file", filepath.Join(p.HomeDir(), relPath),
+	}
+	if _, _, err := p.Exec(ctx, cmd, nil); err != nil {
+		return nil, fmt.Errorf("failed to generate proposal template: %w", err)
+	}
+
+	fr := dockerutil.NewFileRetriever(p.log, p.DockerClient, p.TestName)
+	bz, err := fr.SingleFileContent(ctx, p.VolumeName, relPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading proposal template file: %w", err)
+	}
+
+	proposal := PenumbraProposal{}
+	if err := toml.Unmarshal(bz, &proposal); err != nil {
+		return nil, fmt.Errorf("error unmarshaling proposal template: %w", err)
+	}
+
+	return proposal, nil
+}
+
+// SubmitProposal submits the proposal from the account of keyName, escrowing deposit of the staking token.
+func (p *PenumbraAppNode) SubmitProposal(ctx context.Context, keyName string, proposal PenumbraProposal, deposit math.Int) error {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(proposal); err != nil {
+		return fmt.Errorf("error marshaling proposal: %w", err)
+	}
+
+	relPath := fmt.Sprintf("proposal_%s.toml", keyName)
+	fw := dockerutil.NewFileWriter(p.log, p.DockerClient, p.TestName)
+	if err := fw.WriteFile(ctx, p.VolumeName, relPath, buf.Bytes()); err != nil {
+		return fmt.Errorf("error writing proposal to file: %w", err)
+	}
+
+	keyPath := filepath.Join(p.HomeDir(), "keys", keyName)
+	pdUrl := fmt.Sprintf("http://%s:8080", p.HostName())
+	cmd := []string{
+		"pcli",
+		"-d", keyPath,
+		"-n", pdUrl,
+		"tx", "proposal", "submit",
+		"--file", filepath.Join(p.HomeDir(), relPath),
+		"--deposit-amount", deposit.String(),
+	}
+	if _, _, err := p.Exec(ctx, cmd, nil); err != nil {
+		return fmt.Errorf("failed to submit proposal: %w", err)
+	}
+
+	return nil
+}
+
+// DelegatorVote casts a vote on the proposal with the delegation tokens held by keyName.
+// Only delegation tokens held when voting on the proposal started are counted.
+func (p *PenumbraAppNode) DelegatorVote(ctx context.Context, keyName string, proposalID uint64, vote PenumbraVote) error {
+	arg, err := voteArg(vote)
+	if err != nil {
+		return err
+	}
+
+	keyPath := filepath.Join(p.HomeDir(), "keys", keyName)
+	pdUrl := fmt.Sprintf("http://%s:8080", p.HostName())
+	cmd := []string{
+		"pcli",
+		"-d", keyPath,
+		"-n", pdUrl,
+		"tx", "vote", arg,
+		"--on", strconv.FormatUint(proposalID, 10),
+	}
+	if _, _, err := p.Exec(ctx, cmd, nil); err != nil {
+		return fmt.Errorf("failed to cast delegator vote: %w", err)
+	}
+
+	return nil
+}
+
+// ValidatorVote casts a vote on the proposal on behalf of the validator whose identity belongs to keyName.
+func (p *PenumbraAppNode) ValidatorVote(ctx context.Context, keyName string, proposalID uint64, vote PenumbraVote) error {
+	arg, err := voteArg(vote)
+	if err != nil {
+		return err
+	}
+
+	keyPath := filepath.Join(p.HomeDir(), "keys", keyName)
+	pdUrl := fmt.Sprintf("http://%s:8080", p.HostName())
+	cmd := []string{
+		"pcli",
+		"-d", keyPath,
+		"-n", pdUrl,
+		"validator", "vote", "cast", arg,
+		"--on", strconv.FormatUint(proposalID, 10),
+	}
+	if _, _, err := p.Exec(ctx, cmd, nil); err != nil {
+		return fmt.Errorf("failed to cast validator vote: %w", err)
+	}
+
+	return nil
+}
+
+// ProposalTemplate returns a template for a proposal of the given kind, see PenumbraAppNode.ProposalTemplate.
+func (c *PenumbraChain) ProposalTemplate(ctx context.Context, keyName string, kind PenumbraProposalKind) (PenumbraProposal, error) {
+	return c.getFullNode().PenumbraAppNode.ProposalTemplate(ctx, keyName, kind)
+}
+
+// SubmitProposal submits the proposal from the account of keyName, escrowing deposit of the staking token.
+func (c *PenumbraChain) SubmitProposal(ctx context.Context, keyName string, proposal PenumbraProposal, deposit math.Int) error {
+	return c.getFullNode().PenumbraAppNode.SubmitProposal(ctx, keyName, proposal, deposit)
+}
+
+// DelegatorVote casts a vote on the proposal with the delegation tokens held by keyName.
+func (c *PenumbraChain) DelegatorVote(ctx context.Context, keyName string, proposalID uint64, vote PenumbraVote) error {
+	return c.getFullNode().PenumbraAppNode.DelegatorVote(ctx, keyName, proposalID, vote)
+}
+
+// VoteOnProposalAllValidators casts the same validator vote on the proposal from every validator of the chain.
+func (c *PenumbraChain) VoteOnProposalAllValidators(ctx context.Context, proposalID uint64, vote PenumbraVote) error {
+	var eg errgroup.Group
+	for i, n := range c.PenumbraNodes[:c.numValidators] {
+		i, n := i, n
+		eg.Go(func() error {
+			return n.PenumbraAppNode.ValidatorVote(ctx, fmt.Sprintf("%s-%d", valKey, i), proposalID, vote)
+		})
+	}
+	return eg.Wait()
+}
+
+// ProposalState returns the current state of the proposal with the given ID.
+func (c *PenumbraChain) ProposalState(ctx context.Context, proposalID uint64) (*governancev1alpha1.ProposalState, error) {
+	channel, err := grpc.Dial(c.GetHostGRPCAddress(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer channel.Close()
+
+	queryClient := clientv1alpha1.NewSpecificQueryServiceClient(channel)
+
+	resp, err := queryClient.KeyValue(ctx, &clientv1alpha1.KeyValueRequest{
+		ChainId: c.cfg.ChainID,
+		Key:     proposalStateKey(proposalID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query proposal state: %w", err)
+	}
+	if resp.Value == nil {
+		return nil, fmt.Errorf("proposal %d not found", proposalID)
+	}
+
+	var state governancev1alpha1.ProposalState
+	if err := proto.Unmarshal(resp.Value.Value, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode proposal state: %w", err)
+	}
+
+	return &state, nil
+}
+
+// proposalStateKey returns the key under which pd stores the state of a proposal.
+func proposalStateKey(proposalID uint64) string {
+	return fmt.Sprintf("%s%020d%s", proposalStateKeyPrefix, proposalID, proposalStateKeySuffix)
+}
@@ -0,0 +1,63 @@
+package penumbra
+
+import (
+	"testing"
+
+	governancev1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/governance/v1alpha1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProposalStatus(t *testing.T) {
+	finished := func(outcome *governancev1alpha1.ProposalOutcome) *governancev1alpha1.ProposalState {
+		return &governancev1alpha1.ProposalState{State: &governancev1alpha1.ProposalState_Finished_{
+			Finished: &governancev1alpha1.ProposalState_Finished{Outcome: outcome},
+		}}
+	}
+
+	for _, tt := range []struct {
+		state *governancev1alpha1.ProposalState
+		want  string
+	}{
+		{
+			state: &governancev1alpha1.ProposalState{State: &governancev1alpha1.ProposalState_Voting_{
+				Voting: &governancev1alpha1.ProposalState_Voting{},
+			}},
+			want: ProposalStatusVoting,
+		},
+		{
+			state: finished(&governancev1alpha1.ProposalOutcome{Outcome: &governancev1alpha1.ProposalOutcome_Passed_{
+				Passed: &governancev1alpha1.ProposalOutcome_Passed{},
+			}}),
+			want: ProposalStatusPassed,
+		},
+		{
+			state: finished(&governancev1alpha1.ProposalOutcome{Outcome: &governancev1alpha1.ProposalOutcome_Failed_{
+				Failed: &governancev1alpha1.ProposalOutcome_Failed{},
+			}}),
+			want: ProposalStatusFailed,
+		},
+		{
+			state: &governancev1alpha1.ProposalState{},
+			want:  "",
+		},
+	} {
+		require.Equal(t, tt.want, ProposalStatus(tt.state))
+	}
+
+	require.Equal(t, "governance/proposal/00000000000000000007/state", proposalStateKey(7))
+}
+
+func TestVoteArg(t *testing.T) {
+	for vote, want := range map[PenumbraVote]string{
+		VoteYes:     "yes",
+		VoteNo:      "no",
+		VoteAbstain: "abstain",
+	} {
+		arg, err := voteArg(vote)
+		require.NoError(t, err)
+		require.Equal(t, want, arg)
+	}
+
+	_, err := voteArg(governancev1alpha1.Vote_VOTE_UNSPECIFIED)
+	require.Error(t, err)
+}
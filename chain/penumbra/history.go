@@ -0,0 +1,252 @@
+package penumbra
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"cosmossdk.io/math"
+	cryptov1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/crypto/v1alpha1"
+	transactionv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/transaction/v1alpha1"
+	viewv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/view/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Kinds of transaction actions, as reported in PenumbraTxInfo.Actions.
+const (
+	ActionSpend           = "Spend"
+	ActionOutput          = "Output"
+	ActionSwap            = "Swap"
+	ActionSwapClaim       = "SwapClaim"
+	ActionIbcAction       = "IbcAction"
+	ActionIcs20Withdrawal = "Ics20Withdrawal"
+	ActionDelegate        = "Delegate"
+	ActionUndelegate      = "Undelegate"
+)
+
+// PenumbraTxInfo describes a transaction visible to a client.
+type PenumbraTxInfo struct {
+	Height uint64
+	// Hash is the upper case hex encoded transaction hash, matching ibc.Tx.TxHash.
+	Hash string
+	// Actions holds the kind of each action in the transaction body, in order, e.g. ActionSpend.
+	Actions []string
+
+	Transaction *transactionv1alpha1.Transaction
+}
+
+// ActionCount returns the number of actions of the given kind in the transaction.
+func (t PenumbraTxInfo) ActionCount(kind string) int {
+	var n int
+	for _, a := range t.Actions {
+		if a == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// PenumbraNote describes a note controlled by a client.
+type PenumbraNote struct {
+	Commitment []byte
+	AssetID    []byte
+	Amount     math.Int
+	// Account is the index of the account the note was sent to.
+	Account   uint32
+	Nullifier []byte
+
+	HeightCreated uint64
+	// HeightSpent is zero if the note has not been spent.
+	HeightSpent uint64
+}
+
+// Spent reports whether the note has been spent.
+func (n PenumbraNote) Spent() bool {
+	return n.HeightSpent != 0
+}
+
+// Transactions returns the transactions visible to the client between startHeight and endHeight, inclusive.
+// An endHeight of zero returns all transactions from startHeight onwards.
+func (p *PenumbraClientNode) Transactions(ctx context.Context, startHeight, endHeight uint64) ([]PenumbraTxInfo, error) {
+	channel, err := grpc.Dial(p.hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer channel.Close()
+
+	viewClient := viewv1alpha1.NewViewProtocolServiceClient(channel)
+
+	stream, err := viewClient.TransactionInfo(ctx, &viewv1alpha1.TransactionInfoRequest{
+		StartHeight: startHeight,
+		EndHeight:   endHeight,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var txs []PenumbraTxInfo
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			// A gRPC streaming response will return EOF when it's done.
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		txs = append(txs, newTxInfo(resp.TxInfo))
+	}
+
+	return txs, nil
+}
+
+// TransactionByHash returns the transaction with the given hex encoded hash, if it is visible to the client.
+func (p *PenumbraClientNode) TransactionByHash(ctx context.Context, txHash string) (PenumbraTxInfo, error) {
+	hash, err := hex.DecodeString(txHash)
+	if err != nil {
+		return PenumbraTxInfo{}, fmt.Errorf("failed to decode tx hash: %w", err)
+	}
+
+	channel, err := grpc.Dial(p.hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return PenumbraTxInfo{}, err
+	}
+	defer channel.Close()
+
+	viewClient := viewv1alpha1.NewViewProtocolServiceClient(channel)
+
+	resp, err := viewClient.TransactionInfoByHash(ctx, &viewv1alpha1.TransactionInfoByHashRequest{
+		Id: &transactionv1alpha1.Id{Hash: hash},
+	})
+	if err != nil {
+		return PenumbraTxInfo{}, err
+	}
+	if resp.TxInfo == nil {
+		return PenumbraTxInfo{}, fmt.Errorf("transaction %s not found", txHash)
+	}
+
+	return newTxInfo(resp.TxInfo), nil
+}
+
+// Notes returns the notes sent to the specified account of the client's spend key.
+// Spent notes are only included if includeSpent is true.
+func (p *PenumbraClientNode) Notes(ctx context.Context, account uint32, includeSpent bool) ([]PenumbraNote, error) {
+	channel, err := grpc.Dial(p.hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer channel.Close()
+
+	viewClient := viewv1alpha1.NewViewProtocolServiceClient(channel)
+
+	stream, err := viewClient.Notes(ctx, &viewv1alpha1.NotesRequest{
+		IncludeSpent: includeSpent,
+		AddressIndex: &cryptov1alpha1.AddressIndex{Account: account},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var notes []PenumbraNote
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			// A gRPC streaming response will return EOF when it's done.
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		notes = append(notes, newNote(resp.NoteRecord))
+	}
+
+	return notes, nil
+}
+
+// NullifierSpent reports whether the nullifier has been revealed on chain, i.e. whether its note was spent.
+func (p *PenumbraClientNode) NullifierSpent(ctx context.Context, nullifier []byte) (bool, error) {
+	channel, err := grpc.Dial(p.hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return false, err
+	}
+	defer channel.Close()
+
+	viewClient := viewv1alpha1.NewViewProtocolServiceClient(channel)
+
+	resp, err := viewClient.NullifierStatus(ctx, &viewv1alpha1.NullifierStatusRequest{
+		Nullifier: &cryptov1alpha1.Nullifier{Inner: nullifier},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return resp.Spent, nil
+}
+
+// Assets returns the denom metadata of every asset known to the client.
+func (p *PenumbraClientNode) Assets(ctx context.Context) ([]*cryptov1alpha1.DenomMetadata, error) {
+	channel, err := grpc.Dial(p.hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer channel.Close()
+
+	viewClient := viewv1alpha1.NewViewProtocolServiceClient(channel)
+
+	stream, err := viewClient.Assets(ctx, &viewv1alpha1.AssetsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	var assets []*cryptov1alpha1.DenomMetadata
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			// A gRPC streaming response will return EOF when it's done.
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		assets = append(assets, resp.DenomMetadata)
+	}
+
+	return assets, nil
+}
+
+func newTxInfo(info *viewv1alpha1.TransactionInfo) PenumbraTxInfo {
+	tx := PenumbraTxInfo{
+		Height:      info.GetHeight(),
+		Hash:        strings.ToUpper(hex.EncodeToString(info.GetId().GetHash())),
+		Transaction: info.GetTransaction(),
+	}
+	for _, action := range info.GetTransaction().GetBody().GetActions() {
+		tx.Actions = append(tx.Actions, actionKind(action))
+	}
+	return tx
+}
+
+// actionKind returns the name of the oneof variant set on the action, e.g. "Spend" for *Action_Spend.
+func actionKind(action *transactionv1alpha1.Action) string {
+	if action.GetAction() == nil {
+		return ""
+	}
+	name := fmt.Sprintf("%T", action.GetAction())
+	return name[strings.LastIndex(name, "Action_")+len("Action_"):]
+}
+
+func newNote(record *viewv1alpha1.SpendableNoteRecord) PenumbraNote {
+	amount := record.GetNote().GetValue().GetAmount()
+	return PenumbraNote{
+		Commitment:    record.GetNoteCommitment().GetInner(),
+		AssetID:       record.GetNote().GetValue().GetAssetId().GetInner(),
+		Amount:        translateHiAndLo(amount.GetHi(), amount.GetLo()),
+		Account:       record.GetAddressIndex().GetAccount(),
+		Nullifier:     record.GetNullifier().GetInner(),
+		HeightCreated: record.GetHeightCreated(),
+		HeightSpent:   record.GetHeightSpent(),
+	}
+}
@@ -0,0 +1,31 @@
+package penumbra
+
+import (
+	"testing"
+
+	transactionv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/transaction/v1alpha1"
+	viewv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/view/v1alpha1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTxInfo(t *testing.T) {
+	info := &viewv1alpha1.TransactionInfo{
+		Height: 10,
+		Id:     &transactionv1alpha1.Id{Hash: []byte{0xab, 0xcd}},
+		Transaction: &transactionv1alpha1.Transaction{Body: &transactionv1alpha1.TransactionBody{
+			Actions: []*transactionv1alpha1.Action{
+				{Action: &transactionv1alpha1.Action_Spend{}},
+				{Action: &transactionv1alpha1.Action_Output{}},
+				{Action: &transactionv1alpha1.Action_Output{}},
+				{Action: &transactionv1alpha1.Action_Ics20Withdrawal{}},
+			},
+		}},
+	}
+
+	tx := newTxInfo(info)
+	require.Equal(t, uint64(10), tx.Height)
+	require.Equal(t, "ABCD", tx.Hash)
+	require.Equal(t, []string{ActionSpend, ActionOutput, ActionOutput, ActionIcs20Withdrawal}, tx.Actions)
+	require.Equal(t, 2, tx.ActionCount(ActionOutput))
+	require.Zero(t, tx.ActionCount(ActionSwap))
+}
@@ -1,6 +1,7 @@
 package penumbra
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -8,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/BurntSushi/toml"
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
 	dockerclient "github.com/docker/docker/client"
@@ -159,6 +161,81 @@ func (p *PenumbraAppNode) InitValidatorFile(ctx context.Context, valKeyName stri
 	return err
 }
 
+// ValidatorDefinitionTemplate generates a new validator definition template for the validator identity
+// belonging to keyName. Unlike InitValidatorFile, the template is returned rather than left on the volume,
+// so that it can be modified and uploaded with UploadValidatorDefinition once the chain is running.
+func (p *PenumbraAppNode) ValidatorDefinitionTemplate(ctx context.Context, keyName string) (PenumbraValidatorDefinition, error) {
+	relPath := fmt.Sprintf("validator_%s.toml", keyName)
+	keyPath := filepath.Join(p.HomeDir(), "keys", keyName)
+	cmd := []string{
+		"pcli",
+		"-d", keyPath,
+		"validator", "definition", "template",
+		"--file", filepath.Join(p.HomeDir(), relPath),
+	}
+	if _, _, err := p.Exec(ctx, cmd, nil); err != nil {
+		return PenumbraValidatorDefinition{}, fmt.Errorf("failed to generate validator definition template: %w", err)
+	}
+
+	fr := dockerutil.NewFileRetriever(p.log, p.DockerClient, p.TestName)
+	bz, err := fr.SingleFileContent(ctx, p.VolumeName, relPath)
+	if err != nil {
+		return PenumbraValidatorDefinition{}, fmt.Errorf("error reading validator definition template file: %w", err)
+	}
+
+	var def PenumbraValidatorDefinition
+	if err := toml.Unmarshal(bz, &def); err != nil {
+		return PenumbraValidatorDefinition{}, fmt.Errorf("error unmarshaling validator definition template: %w", err)
+	}
+
+	return def, nil
+}
+
+// UploadValidatorDefinition signs the validator definition with the identity key belonging to keyName
+// and submits it to the chain, either declaring a new validator or updating an existing one.
+// Updates to an existing validator must use a higher SequenceNumber than the current definition.
+func (p *PenumbraAppNode) UploadValidatorDefinition(ctx context.Context, keyName string, def PenumbraValidatorDefinition) error {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(def); err != nil {
+		return fmt.Errorf("error marshaling validator definition: %w", err)
+	}
+
+	relPath := fmt.Sprintf("validator_%s.toml", keyName)
+	fw := dockerutil.NewFileWriter(p.log, p.DockerClient, p.TestName)
+	if err := fw.WriteFile(ctx, p.VolumeName, relPath, buf.Bytes()); err != nil {
+		return fmt.Errorf("error writing validator definition to file: %w", err)
+	}
+
+	keyPath := filepath.Join(p.HomeDir(), "keys", keyName)
+	pdUrl := fmt.Sprintf("http://%s:8080", p.HostName())
+	cmd := []string{
+		"pcli",
+		"-d", keyPath,
+		"-n", pdUrl,
+		"validator", "definition", "upload",
+		"--file", filepath.Join(p.HomeDir(), relPath),
+	}
+	if _, _, err := p.Exec(ctx, cmd, nil); err != nil {
+		return fmt.Errorf("failed to upload validator definition: %w", err)
+	}
+
+	return nil
+}
+
+// UndelegateClaim claims the staking tokens of all undelegations made by keyName that have finished unbonding.
+// Claims are submitted with pcli because the view service transaction planner cannot plan them.
+func (p *PenumbraAppNode) UndelegateClaim(ctx context.Context, keyName string) error {
+	keyPath := filepath.Join(p.HomeDir(), "keys", keyName)
+	pdUrl := fmt.Sprintf("http://%s:8080", p.HostName())
+	cmd := []string{"pcli", "-d", keyPath, "-n", pdUrl, "tx", "undelegate-claim"}
+
+	if _, _, err := p.Exec(ctx, cmd, nil); err != nil {
+		return fmt.Errorf("failed to claim undelegations: %w", err)
+	}
+
+	return nil
+}
+
 func (p *PenumbraAppNode) ValidatorDefinitionTemplateFilePathContainer() string {
 	return filepath.Join(p.HomeDir(), "validator.toml")
 }
@@ -293,6 +370,10 @@ func (p *PenumbraAppNode) StopContainer(ctx context.Context) error {
 	return p.containerLifecycle.StopContainer(ctx)
 }
 
+func (p *PenumbraAppNode) RemoveContainer(ctx context.Context) error {
+	return p.containerLifecycle.RemoveContainer(ctx)
+}
+
 func (p *PenumbraAppNode) StartContainer(ctx context.Context) error {
 	if err := p.containerLifecycle.StartContainer(ctx); err != nil {
 		return err
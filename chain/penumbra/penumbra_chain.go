@@ -2,9 +2,11 @@ package penumbra
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -15,10 +17,13 @@ import (
 	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/gogoproto/proto"
+	chanTypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/client"
 	"github.com/strangelove-ventures/interchaintest/v8/chain/internal/tendermint"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/internal/blockdb"
 	"github.com/strangelove-ventures/interchaintest/v8/internal/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v8/testutil"
 	"go.uber.org/zap"
@@ -83,12 +88,54 @@ func NewPenumbraChain(log *zap.Logger, testName string, chainConfig ibc.ChainCon
 	}
 }
 
+// Acknowledgements implements ibc.Chain, returning all acknowledgments in block at height
 func (c *PenumbraChain) Acknowledgements(ctx context.Context, height uint64) ([]ibc.PacketAcknowledgement, error) {
-	panic("implement me")
+	var acks []*chanTypes.MsgAcknowledgement
+	err := rangeBlockIbcMessages(ctx, c.getFullNode().TendermintNode.Client, height, func(msg proto.Message) bool {
+		found, ok := msg.(*chanTypes.MsgAcknowledgement)
+		if ok {
+			acks = append(acks, found)
+		}
+		return false
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find acknowledgements at height %d: %w", height, err)
+	}
+	ibcAcks := make([]ibc.PacketAcknowledgement, len(acks))
+	for i, ack := range acks {
+		ibcAcks[i] = ibc.PacketAcknowledgement{
+			Acknowledgement: ack.Acknowledgement,
+			Packet:          ibcPacket(ack.Packet),
+		}
+	}
+	return ibcAcks, nil
 }
 
+// Timeouts implements ibc.Chain, returning all timeouts in block at height
 func (c *PenumbraChain) Timeouts(ctx context.Context, height uint64) ([]ibc.PacketTimeout, error) {
-	panic("implement me")
+	var timeouts []*chanTypes.MsgTimeout
+	err := rangeBlockIbcMessages(ctx, c.getFullNode().TendermintNode.Client, height, func(msg proto.Message) bool {
+		found, ok := msg.(*chanTypes.MsgTimeout)
+		if ok {
+			timeouts = append(timeouts, found)
+		}
+		return false
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find timeouts at height %d: %w", height, err)
+	}
+	ibcTimeouts := make([]ibc.PacketTimeout, len(timeouts))
+	for i, timeout := range timeouts {
+		ibcTimeouts[i] = ibc.PacketTimeout{
+			Packet: ibcPacket(timeout.Packet),
+		}
+	}
+	return ibcTimeouts, nil
+}
+
+// FindTxs implements blockdb.BlockSaver.
+func (c *PenumbraChain) FindTxs(ctx context.Context, height uint64) ([]blockdb.Tx, error) {
+	return findTxs(ctx, c.getFullNode().TendermintNode.Client, height)
 }
 
 // Implements Chain interface
@@ -106,11 +153,38 @@ func (c *PenumbraChain) Exec(ctx context.Context, cmd []string, env []string) (s
 	return c.getFullNode().PenumbraAppNode.Exec(ctx, cmd, env)
 }
 
+// NodeContainers returns the containers of all nodes, validators first:
+// the CometBFT and pd containers of each node, followed by its pclientd containers ordered by key name.
+func (c *PenumbraChain) NodeContainers() []ibc.NodeContainers {
+	containers := make([]ibc.NodeContainers, len(c.PenumbraNodes))
+	for i, n := range c.PenumbraNodes {
+		clientNodes := n.clientNodes()
+		sort.Slice(clientNodes, func(a, b int) bool {
+			return clientNodes[a].KeyName < clientNodes[b].KeyName
+		})
+
+		containers[i] = ibc.NodeContainers{
+			Validator:  i < c.numValidators,
+			Containers: []string{n.TendermintNode.Name(), n.PenumbraAppNode.Name()},
+		}
+		for _, clientNode := range clientNodes {
+			containers[i].Containers = append(containers[i].Containers, clientNode.Name())
+		}
+	}
+	return containers
+}
+
 func (c *PenumbraChain) getFullNode() *PenumbraNode {
 	// use first validator
 	return c.PenumbraNodes[0]
 }
 
+// started reports whether the chain's nodes have been started,
+// i.e. whether pd is reachable by new pclientd instances.
+func (c *PenumbraChain) started() bool {
+	return len(c.PenumbraNodes) > 0 && c.getFullNode().PenumbraAppNode.hostGRPCPort != ""
+}
+
 // Implements Chain interface
 func (c *PenumbraChain) GetRPCAddress() string {
 	return fmt.Sprintf("http://%s:26657", c.getFullNode().TendermintNode.HostName())
@@ -156,17 +230,46 @@ func (c *PenumbraChain) RecoverKey(ctx context.Context, name, mnemonic string) e
 }
 
 // GetAddress returns the byte representation of an address for the specified keyName.
+// If a pclientd instance exists for keyName the address is derived by its view service,
+// otherwise it falls back to deriving the address with pcli.
 func (c *PenumbraChain) GetAddress(ctx context.Context, keyName string) ([]byte, error) {
 	fn := c.getFullNode()
+	if clientNode, ok := fn.clientNode(keyName); ok {
+		return clientNode.GetAddress(ctx)
+	}
+
 	if fn.PenumbraAppNode == nil {
 		return nil, fmt.Errorf("no penumbra app nodes configured to retreive an address from")
 	}
 	return fn.PenumbraAppNode.GetAddress(ctx, keyName)
 }
 
+// GetAddressByIndex returns the byte representation of the address for the specified account and subaccount
+// belonging to keyName, as derived by the view service of the pclientd instance associated with keyName.
+func (c *PenumbraChain) GetAddressByIndex(ctx context.Context, keyName string, account, subaccount uint32) ([]byte, error) {
+	clientNode, err := c.clientNode(keyName)
+	if err != nil {
+		return nil, err
+	}
+	return clientNode.GetAddressByIndex(ctx, account, subaccount)
+}
+
+// clientNode returns the pclientd instance associated with keyName on the full node.
+func (c *PenumbraChain) clientNode(keyName string) (*PenumbraClientNode, error) {
+	clientNode, ok := c.getFullNode().clientNode(keyName)
+	if !ok {
+		return nil, fmt.Errorf("no pclientd instance found for key %s", keyName)
+	}
+	return clientNode, nil
+}
+
 // BuildWallet will return a Penumbra wallet
 // If mnemonic != "", it will restore using that mnemonic
 // If mnemonic == "", it will create a new key
+// Once the chain has started, BuildWallet also creates and starts a pclientd instance for the key, as CreateClientNode
+// does, and the wallet address is derived by its view service with AddressByIndex.
+// Before the chain starts, e.g. for genesis wallets, pd cannot serve a pclientd instance yet,
+// so the address is derived with pcli and CreateClientNode must be called once the chain is running.
 func (c *PenumbraChain) BuildWallet(ctx context.Context, keyName string, mnemonic string) (ibc.Wallet, error) {
 	if mnemonic != "" {
 		if err := c.RecoverKey(ctx, keyName, mnemonic); err != nil {
@@ -178,6 +281,12 @@ func (c *PenumbraChain) BuildWallet(ctx context.Context, keyName string, mnemoni
 		}
 	}
 
+	if c.started() {
+		if err := c.CreateClientNode(ctx, keyName); err != nil {
+			return nil, fmt.Errorf("failed to create pclientd instance for key %q on chain %s: %w", keyName, c.cfg.Name, err)
+		}
+	}
+
 	addrBytes, err := c.GetAddress(ctx, keyName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get account address for key %q on chain %s: %w", keyName, c.cfg.Name, err)
@@ -195,7 +304,7 @@ func (c *PenumbraChain) BuildRelayerWallet(ctx context.Context, keyName string)
 		return nil, fmt.Errorf("invalid coin type: %w", err)
 	}
 
-	info, mnemonic, err := c.keyring.NewMnemonic(
+	_, mnemonic, err := c.keyring.NewMnemonic(
 		keyName,
 		keyring.English,
 		hd.CreateHDPath(uint32(coinType), 0, 0).String(),
@@ -206,7 +315,13 @@ func (c *PenumbraChain) BuildRelayerWallet(ctx context.Context, keyName string)
 		return nil, fmt.Errorf("failed to create mnemonic: %w", err)
 	}
 
-	addrBytes, err := info.GetAddress()
+	// Import the mnemonic as a Penumbra spend key so that the wallet holds a valid bech32m address,
+	// which is required for the relayer wallet to be funded in genesis.
+	if err := c.RecoverKey(ctx, keyName, mnemonic); err != nil {
+		return nil, fmt.Errorf("failed to recover relayer key %q on chain %s: %w", keyName, c.cfg.Name, err)
+	}
+
+	addrBytes, err := c.GetAddress(ctx, keyName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get address: %w", err)
 	}
@@ -226,8 +341,19 @@ func (c *PenumbraChain) SendFunds(ctx context.Context, keyName string, amount ib
 	return fn.PenumbraClientNodes[keyName].SendFunds(ctx, amount)
 }
 
+// SendFundsFromAccount will initiate a local transfer from the specified account of the spend key associated with
+// keyName to the address in amount.
+func (c *PenumbraChain) SendFundsFromAccount(ctx context.Context, keyName string, account uint32, amount ibc.WalletAmount, opts ...TxOption) error {
+	clientNode, err := c.clientNode(keyName)
+	if err != nil {
+		return err
+	}
+	return clientNode.SendFundsFromAccount(ctx, account, amount, opts...)
+}
+
 // SendIBCTransfer attempts to send a fungible token transfer via IBC from the specified account on the source chain
 // to the specified account on the counterparty chain.
+// Packet memos are not supported by this Penumbra version, so options.Memo must be empty.
 func (c *PenumbraChain) SendIBCTransfer(
 	ctx context.Context,
 	channelID string,
@@ -239,11 +365,64 @@ func (c *PenumbraChain) SendIBCTransfer(
 	if len(fn.PenumbraClientNodes) == 0 {
 		return ibc.Tx{}, fmt.Errorf("no pclientd instances on the node for ibc transfers")
 	}
-	return fn.PenumbraClientNodes[keyName].SendIBCTransfer(ctx, channelID, amount, options)
-}
 
-func (c *PenumbraChain) ExportState(ctx context.Context, height int64) (string, error) {
-	panic("implement me")
+	clientNode, ok := fn.clientNode(keyName)
+	if !ok {
+		return ibc.Tx{}, fmt.Errorf("no pclientd instance found for key %s", keyName)
+	}
+
+	tx, err := clientNode.SendIBCTransfer(ctx, channelID, amount, options)
+	if err != nil {
+		return tx, fmt.Errorf("send ibc transfer: %w", err)
+	}
+
+	hash, err := hex.DecodeString(tx.TxHash)
+	if err != nil {
+		return tx, fmt.Errorf("invalid transaction hash %s: %w", tx.TxHash, err)
+	}
+
+	txResp, err := fn.TendermintNode.Client.Tx(ctx, hash, false)
+	if err != nil {
+		return tx, fmt.Errorf("failed to get transaction %s: %w", tx.TxHash, err)
+	}
+	if txResp.TxResult.Code != 0 {
+		return tx, fmt.Errorf("error in transaction (code: %d): %s", txResp.TxResult.Code, txResp.TxResult.Log)
+	}
+	tx.Height = uint64(txResp.Height)
+
+	const evType = "send_packet"
+	events := txResp.TxResult.Events
+
+	var (
+		seq, _           = tendermint.AttributeValue(events, evType, "packet_sequence")
+		srcPort, _       = tendermint.AttributeValue(events, evType, "packet_src_port")
+		srcChan, _       = tendermint.AttributeValue(events, evType, "packet_src_channel")
+		dstPort, _       = tendermint.AttributeValue(events, evType, "packet_dst_port")
+		dstChan, _       = tendermint.AttributeValue(events, evType, "packet_dst_channel")
+		timeoutHeight, _ = tendermint.AttributeValue(events, evType, "packet_timeout_height")
+		timeoutTs, _     = tendermint.AttributeValue(events, evType, "packet_timeout_timestamp")
+		data, _          = tendermint.AttributeValue(events, evType, "packet_data")
+	)
+	tx.Packet.SourcePort = srcPort
+	tx.Packet.SourceChannel = srcChan
+	tx.Packet.DestPort = dstPort
+	tx.Packet.DestChannel = dstChan
+	tx.Packet.TimeoutHeight = timeoutHeight
+	tx.Packet.Data = []byte(data)
+
+	seqNum, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return tx, fmt.Errorf("invalid packet sequence from events %s: %w", seq, err)
+	}
+	tx.Packet.Sequence = seqNum
+
+	timeoutNano, err := strconv.ParseUint(timeoutTs, 10, 64)
+	if err != nil {
+		return tx, fmt.Errorf("invalid packet timestamp timeout %s: %w", timeoutTs, err)
+	}
+	tx.Packet.TimeoutTimestamp = ibc.Nanoseconds(timeoutNano)
+
+	return tx, nil
 }
 
 // Height returns the current chain block height.
@@ -267,12 +446,32 @@ func (c *PenumbraChain) GetBalance(ctx context.Context, keyName string, denom st
 	return bal, nil
 }
 
-// GetGasFeesInNativeDenom returns the fees used to pay for some compute with the local token denom,
-// where fees = gasPaid * gasPrice.
+// GetBalanceByAccount returns the balance of the specified denom in the specified account of the spend key
+// associated with keyName.
+func (c *PenumbraChain) GetBalanceByAccount(ctx context.Context, keyName string, account uint32, denom string) (math.Int, error) {
+	clientNode, err := c.clientNode(keyName)
+	if err != nil {
+		return math.Int{}, err
+	}
+	return clientNode.GetBalanceByAccount(ctx, account, denom)
+}
+
+// GetAllBalances returns the balances of every asset held in the specified account of the spend key
+// associated with keyName, see PenumbraClientNode.GetAllBalances.
+func (c *PenumbraChain) GetAllBalances(ctx context.Context, keyName string, account uint32) (map[string]math.Int, error) {
+	clientNode, err := c.clientNode(keyName)
+	if err != nil {
+		return nil, err
+	}
+	return clientNode.GetAllBalances(ctx, account)
+}
+
+// GetGasFeesInNativeDenom returns the fees used to pay for some compute with the local token denom.
+// Penumbra has no gas: the fee of a transaction is chosen when planning it, see TxOption. Transactions of this
+// chain report the fee they paid as their GasSpent, so gasPaid is already the fee and is returned unchanged.
+// Use TxFee to look up the fee paid by any transaction.
 func (c *PenumbraChain) GetGasFeesInNativeDenom(gasPaid int64) int64 {
-	gasPrice, _ := strconv.ParseFloat(strings.Replace(c.cfg.GasPrices, c.cfg.Denom, "", 1), 64)
-	fees := float64(gasPaid) * gasPrice
-	return int64(fees)
+	return gasPaid
 }
 
 // creates the test node objects required for bootstrapping tests
@@ -285,7 +484,21 @@ func (c *PenumbraChain) initializeChainNodes(
 	var penumbraNodes []*PenumbraNode
 	count := c.numValidators + c.numFullNodes
 	chainCfg := c.Config()
-	for _, image := range chainCfg.Images {
+	c.pullImages(ctx, cli)
+	for i := 0; i < count; i++ {
+		pn, err := NewPenumbraNode(ctx, i, c, cli, networkID, testName, chainCfg.Images[0], chainCfg.Images[1])
+		if err != nil {
+			return err
+		}
+		penumbraNodes = append(penumbraNodes, &pn)
+	}
+	c.PenumbraNodes = penumbraNodes
+
+	return nil
+}
+
+func (c *PenumbraChain) pullImages(ctx context.Context, cli *client.Client) {
+	for _, image := range c.Config().Images {
 		rc, err := cli.ImagePull(
 			ctx,
 			image.Repository+":"+image.Version,
@@ -302,16 +515,6 @@ func (c *PenumbraChain) initializeChainNodes(
 			_ = rc.Close()
 		}
 	}
-	for i := 0; i < count; i++ {
-		pn, err := NewPenumbraNode(ctx, i, c, cli, networkID, testName, chainCfg.Images[0], chainCfg.Images[1])
-		if err != nil {
-			return err
-		}
-		penumbraNodes = append(penumbraNodes, &pn)
-	}
-	c.PenumbraNodes = penumbraNodes
-
-	return nil
 }
 
 type GenesisValidatorPubKey struct {
@@ -499,33 +702,11 @@ func (c *PenumbraChain) start(ctx context.Context) error {
 		return err
 	}
 
-	eg, egCtx := errgroup.WithContext(ctx)
-	for _, n := range c.PenumbraNodes {
-		n := n
-
-		sep, err := n.TendermintNode.GetConfigSeparator()
-		if err != nil {
-			return err
-		}
-
-		tmPort := strings.Split(rpcPort, "/")[0]
-		eg.Go(func() error {
-			return n.TendermintNode.CreateNodeContainer(
-				egCtx,
-				fmt.Sprintf("--proxy%sapp=tcp://%s:%s", sep, n.PenumbraAppNode.HostName(), strings.Split(abciPort, "/")[0]),
-				"--rpc.laddr=tcp://0.0.0.0:"+tmPort,
-			)
-		})
-
-		eg.Go(func() error {
-			return n.PenumbraAppNode.CreateNodeContainer(egCtx, n.TendermintNode.HostName()+":"+tmPort)
-		})
-	}
-	if err := eg.Wait(); err != nil {
+	if err := c.createNodeContainers(ctx); err != nil {
 		return err
 	}
 
-	eg, egCtx = errgroup.WithContext(ctx)
+	eg, egCtx := errgroup.WithContext(ctx)
 	for _, n := range c.PenumbraNodes {
 		n := n
 
@@ -596,6 +777,8 @@ func (c *PenumbraChain) start(ctx context.Context) error {
 	return eg.Wait()
 }
 
+// CreateClientNode creates and starts a pclientd instance for the specified keyName.
+// It is a nop if a pclientd instance already exists for keyName.
 func (c PenumbraChain) CreateClientNode(
 	ctx context.Context,
 	keyName string,
@@ -605,6 +788,10 @@ func (c PenumbraChain) CreateClientNode(
 		return fmt.Errorf("there are no penumbra nodes configured to create a client on")
 	}
 
+	if _, ok := val.clientNode(keyName); ok {
+		return nil
+	}
+
 	fr := dockerutil.NewFileRetriever(c.log, val.PenumbraAppNode.DockerClient, val.PenumbraAppNode.TestName)
 	ckbz, err := fr.SingleFileContent(ctx, val.PenumbraAppNode.VolumeName, fmt.Sprintf("keys/%s/custody.json", keyName))
 	if err != nil {
@@ -638,3 +825,75 @@ func (c PenumbraChain) CreateClientNode(
 		fvk,
 	)
 }
+
+// StopClientNode stops the pclientd instance associated with keyName, keeping its container and state.
+// Use RestartClientNode to bring it back up.
+func (c *PenumbraChain) StopClientNode(ctx context.Context, keyName string) error {
+	clientNode, err := c.clientNode(keyName)
+	if err != nil {
+		return err
+	}
+	return clientNode.StopContainer(ctx)
+}
+
+// RestartClientNode restarts the pclientd instance associated with keyName, connecting it to the pd instance of
+// the node at nodeIndex in PenumbraNodes. If resync is true, the client's state is wiped so that it resyncs from genesis.
+// Use WaitForClientNodeSync to wait for the client to catch up with the chain afterwards.
+func (c *PenumbraChain) RestartClientNode(ctx context.Context, keyName string, nodeIndex int, resync bool) error {
+	if nodeIndex < 0 || nodeIndex >= len(c.PenumbraNodes) {
+		return fmt.Errorf("node index %d out of range, chain has %d nodes", nodeIndex, len(c.PenumbraNodes))
+	}
+
+	clientNode, err := c.clientNode(keyName)
+	if err != nil {
+		return err
+	}
+
+	pdAddress := c.PenumbraNodes[nodeIndex].pdAddress()
+	if resync {
+		return clientNode.ResyncFromGenesis(ctx, pdAddress)
+	}
+	return clientNode.Restart(ctx, pdAddress)
+}
+
+// RemoveClientNode stops and removes the pclientd instance associated with keyName.
+// A new instance can be created for the same key with CreateClientNode.
+func (c *PenumbraChain) RemoveClientNode(ctx context.Context, keyName string) error {
+	return c.getFullNode().RemoveClientNode(ctx, keyName)
+}
+
+// WaitForClientNodeSync blocks until the pclientd instance associated with keyName has synced to the chain tip.
+func (c *PenumbraChain) WaitForClientNodeSync(ctx context.Context, keyName string) error {
+	clientNode, err := c.clientNode(keyName)
+	if err != nil {
+		return err
+	}
+	return clientNode.WaitForSync(ctx)
+}
+
+// createNodeContainers creates the CometBFT and pd containers of every node, connecting each pair over ABCI.
+func (c *PenumbraChain) createNodeContainers(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, n := range c.PenumbraNodes {
+		n := n
+
+		sep, err := n.TendermintNode.GetConfigSeparator()
+		if err != nil {
+			return err
+		}
+
+		tmPort := strings.Split(rpcPort, "/")[0]
+		eg.Go(func() error {
+			return n.TendermintNode.CreateNodeContainer(
+				egCtx,
+				fmt.Sprintf("--proxy%sapp=tcp://%s:%s", sep, n.PenumbraAppNode.HostName(), strings.Split(abciPort, "/")[0]),
+				"--rpc.laddr=tcp://0.0.0.0:"+tmPort,
+			)
+		})
+
+		eg.Go(func() error {
+			return n.PenumbraAppNode.CreateNodeContainer(egCtx, n.TendermintNode.HostName()+":"+tmPort)
+		})
+	}
+	return eg.Wait()
+}
@@ -0,0 +1,42 @@
+package penumbra
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v8/chain/internal/tendermint"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNodeContainers(t *testing.T) {
+	c := NewPenumbraChain(zap.NewNop(), "test", ibc.ChainConfig{ChainID: "penumbra-0"}, 1, 1)
+	for i := 0; i < 2; i++ {
+		c.PenumbraNodes = append(c.PenumbraNodes, &PenumbraNode{
+			TendermintNode:      &tendermint.TendermintNode{Index: i, Chain: c, TestName: "test"},
+			PenumbraAppNode:     &PenumbraAppNode{Index: i, Chain: c, TestName: "test"},
+			PenumbraClientNodes: make(map[string]*PenumbraClientNode),
+			clientsMu:           &sync.Mutex{},
+		})
+	}
+	for _, keyName := range []string{"user", "faucet"} {
+		c.PenumbraNodes[0].PenumbraClientNodes[keyName] = &PenumbraClientNode{KeyName: keyName, Chain: c, TestName: "test"}
+	}
+
+	require.Equal(t, []ibc.NodeContainers{
+		{
+			Validator: true,
+			Containers: []string{
+				"node-0-penumbra-0-test",
+				"pd-0-penumbra-0-test",
+				"pclientd-0-faucet-penumbra-0-test",
+				"pclientd-0-user-penumbra-0-test",
+			},
+		},
+		{
+			Validator:  false,
+			Containers: []string{"node-1-penumbra-0-test", "pd-1-penumbra-0-test"},
+		},
+	}, c.NodeContainers())
+}
@@ -3,18 +3,27 @@ package penumbra
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"math/big"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"cosmossdk.io/math"
 	"github.com/BurntSushi/toml"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	chanTypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	ibctm "github.com/cosmos/ibc-go/v8/modules/light-clients/07-tendermint"
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
 	clientv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/client/v1alpha1"
 	cryptov1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/crypto/v1alpha1"
+	ibcv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/ibc/v1alpha1"
+	transactionv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/transaction/v1alpha1"
 	custodyv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/custody/v1alpha1"
 	viewv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/view/v1alpha1"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
@@ -42,6 +51,9 @@ type PenumbraClientNode struct {
 
 	containerLifecycle *dockerutil.ContainerLifecycle
 
+	// Set during CreateNodeContainer.
+	pdAddress string
+
 	// Set during StartContainer.
 	hostGRPCPort string
 }
@@ -102,6 +114,9 @@ func NewClientNode(
 
 const (
 	pclientdPort = "8081/tcp"
+
+	// pclientdDatabase is the view service state database, relative to the home directory.
+	pclientdDatabase = "pclientd-db.sqlite"
 )
 
 var pclientdPorts = nat.PortSet{
@@ -127,12 +142,76 @@ func (p *PenumbraClientNode) HomeDir() string {
 	return "/home/pclientd"
 }
 
+// GetAddress returns the bech32m encoded address of the client's default account.
 func (p *PenumbraClientNode) GetAddress(ctx context.Context) ([]byte, error) {
-	// TODO make grpc call to pclientd to get address
-	panic("not yet implemented")
+	return p.GetAddressByIndex(ctx, 0, 0)
 }
 
-func (p *PenumbraClientNode) SendFunds(ctx context.Context, amount ibc.WalletAmount) error {
+// GetAddressByIndex returns the bech32m encoded address for the specified account and subaccount, derived by the
+// view service from the client's full viewing key. Subaccount 0 is the default address of the account.
+func (p *PenumbraClientNode) GetAddressByIndex(ctx context.Context, account, subaccount uint32) ([]byte, error) {
+	channel, err := grpc.Dial(
+		p.hostGRPCPort,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer channel.Close()
+
+	viewClient := viewv1alpha1.NewViewProtocolServiceClient(channel)
+
+	req := &viewv1alpha1.AddressByIndexRequest{
+		AddressIndex: addressIndex(account, subaccount),
+	}
+
+	resp, err := viewClient.AddressByIndex(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	addr, err := addressString(resp.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(addr), nil
+}
+
+// addressIndex builds the AddressIndex for an account and subaccount. Penumbra derives any number of unlinkable
+// addresses per account from a 12 byte randomizer, the subaccount is encoded little-endian into its first 4 bytes.
+func addressIndex(account, subaccount uint32) *cryptov1alpha1.AddressIndex {
+	index := &cryptov1alpha1.AddressIndex{Account: account}
+	if subaccount != 0 {
+		index.Randomizer = make([]byte, 12)
+		binary.LittleEndian.PutUint32(index.Randomizer, subaccount)
+	}
+	return index
+}
+
+// addressString returns the bech32m representation of a Penumbra address, encoding the inner bytes if the view
+// service did not populate the alternative string representation.
+func addressString(addr *cryptov1alpha1.Address) (string, error) {
+	if addr == nil {
+		return "", fmt.Errorf("address not found")
+	}
+	if addr.AltBech32M != "" {
+		return addr.AltBech32M, nil
+	}
+	if len(addr.Inner) == 0 {
+		return "", fmt.Errorf("address is empty")
+	}
+	return encodeAddress(addr.Inner)
+}
+
+// SendFunds sends funds from the client's default account to the address in amount.
+func (p *PenumbraClientNode) SendFunds(ctx context.Context, amount ibc.WalletAmount, opts ...TxOption) error {
+	return p.SendFundsFromAccount(ctx, 0, amount, opts...)
+}
+
+// SendFundsFromAccount sends funds from the specified account of the client's spend key to the address in amount.
+// The address may belong to another account of the same spend key, see GetAddressByIndex.
+func (p *PenumbraClientNode) SendFundsFromAccount(ctx context.Context, account uint32, amount ibc.WalletAmount, opts ...TxOption) error {
 	channel, err := grpc.Dial(p.hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
 		return err
@@ -154,37 +233,62 @@ func (p *PenumbraClientNode) SendFunds(ctx context.Context, amount ibc.WalletAmo
 			},
 			Address: &cryptov1alpha1.Address{AltBech32M: amount.Address},
 		}},
+		Source: &cryptov1alpha1.AddressIndex{Account: account},
 	}
+	applyTxOptions(tpr, opts)
+
+	_, _, err = p.planAndBroadcast(ctx, channel, tpr)
+	return err
+}
 
+// planAndBroadcast generates a transaction plan for the planner request, then has pclientd authorize, build and
+// broadcast the planned transaction.
+func (p *PenumbraClientNode) planAndBroadcast(
+	ctx context.Context,
+	channel *grpc.ClientConn,
+	tpr *viewv1alpha1.TransactionPlannerRequest,
+) (*transactionv1alpha1.Transaction, *viewv1alpha1.BroadcastTransactionResponse, error) {
 	viewClient := viewv1alpha1.NewViewProtocolServiceClient(channel)
 
 	resp, err := viewClient.TransactionPlanner(ctx, tpr)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
+	return p.authorizeAndBroadcast(ctx, channel, resp.Plan)
+}
+
+// authorizeAndBroadcast has pclientd sign and build the transaction plan, then broadcasts the built transaction and
+// awaits its detection by the view service.
+func (p *PenumbraClientNode) authorizeAndBroadcast(
+	ctx context.Context,
+	channel *grpc.ClientConn,
+	plan *transactionv1alpha1.TransactionPlan,
+) (*transactionv1alpha1.Transaction, *viewv1alpha1.BroadcastTransactionResponse, error) {
+	viewClient := viewv1alpha1.NewViewProtocolServiceClient(channel)
+
 	// Get authorization data for the transaction from pclientd (signing).
 	custodyClient := custodyv1alpha1.NewCustodyProtocolServiceClient(channel)
 	authorizeReq := &custodyv1alpha1.AuthorizeRequest{
-		Plan:              resp.Plan,
+		Plan:              plan,
 		AccountGroupId:    &cryptov1alpha1.AccountGroupId{Inner: make([]byte, 32)},
 		PreAuthorizations: []*custodyv1alpha1.PreAuthorization{},
 	}
 
 	authData, err := custodyClient.Authorize(ctx, authorizeReq)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	// Have pclientd build and sign the planned transaction.
 	wbr := &viewv1alpha1.WitnessAndBuildRequest{
-		TransactionPlan:   resp.Plan,
+		TransactionPlan:   plan,
 		AuthorizationData: authData.Data,
 	}
 
 	tx, err := viewClient.WitnessAndBuild(ctx, wbr)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	// Have pclientd broadcast and await confirmation of the built transaction.
@@ -193,25 +297,196 @@ func (p *PenumbraClientNode) SendFunds(ctx context.Context, amount ibc.WalletAmo
 		AwaitDetection: true,
 	}
 
-	_, err = viewClient.BroadcastTransaction(ctx, btr)
+	txResp, err := viewClient.BroadcastTransaction(ctx, btr)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	return nil
+	return tx.Transaction, txResp, nil
 }
 
+// SendIBCTransfer withdraws amount to the counterparty chain of channelID through an ICS-20 transfer.
+// The ICS-20 withdrawal of this protocol version cannot carry a packet memo, so a non-empty options.Memo is an error.
 func (p *PenumbraClientNode) SendIBCTransfer(
 	ctx context.Context,
 	channelID string,
 	amount ibc.WalletAmount,
 	options ibc.TransferOptions,
+	opts ...TxOption,
 ) (ibc.Tx, error) {
-	// TODO make grpc call to pclientd to send ibc transfer
-	panic("not yet implemented")
+	if options.Memo != "" {
+		return ibc.Tx{}, fmt.Errorf("ics20 withdrawals of this penumbra version do not support packet memos")
+	}
+
+	channel, err := grpc.Dial(p.hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return ibc.Tx{}, err
+	}
+	defer channel.Close()
+
+	viewClient := viewv1alpha1.NewViewProtocolServiceClient(channel)
+
+	// Funds that are refunded because of a timeout or error acknowledgement are returned to an ephemeral address
+	// controlled by this client.
+	returnAddr, err := viewClient.EphemeralAddress(ctx, &viewv1alpha1.EphemeralAddressRequest{
+		AddressIndex: &cryptov1alpha1.AddressIndex{Account: 0},
+	})
+	if err != nil {
+		return ibc.Tx{}, fmt.Errorf("failed to get ephemeral return address: %w", err)
+	}
+
+	hi, lo := translateBigInt(amount.Amount)
+	value := &cryptov1alpha1.Value{
+		Amount: &cryptov1alpha1.Amount{
+			Lo: lo,
+			Hi: hi,
+		},
+		AssetId: &cryptov1alpha1.AssetId{AltBaseDenom: amount.Denom},
+	}
+
+	// The planner does not know about ICS-20 withdrawals, so plan an output of the same value to the return address
+	// and swap it out for the withdrawal afterwards. This keeps the plan balanced, since the spends and fee selected
+	// by the planner cover exactly the value leaving the account.
+	tpr := &viewv1alpha1.TransactionPlannerRequest{
+		Outputs: []*viewv1alpha1.TransactionPlannerRequest_Output{{
+			Value:   value,
+			Address: returnAddr.Address,
+		}},
+	}
+	applyTxOptions(tpr, opts)
+
+	resp, err := viewClient.TransactionPlanner(ctx, tpr)
+	if err != nil {
+		return ibc.Tx{}, err
+	}
+
+	var counterpartyHeight clienttypes.Height
+	if options.Timeout != nil && options.Timeout.Height != 0 {
+		counterpartyHeight, err = p.counterpartyHeight(ctx, channelID)
+		if err != nil {
+			return ibc.Tx{}, err
+		}
+	}
+
+	// The withdrawal only holds the revision height of the timeout, pd uses the revision number of the counterparty client.
+	timeoutHeight, timeoutTime := ibcTransferTimeouts(options, counterpartyHeight, time.Now())
+	withdrawal := &ibcv1alpha1.Ics20Withdrawal{
+		Amount:                  value.Amount,
+		Denom:                   &cryptov1alpha1.Denom{Denom: amount.Denom},
+		DestinationChainAddress: amount.Address,
+		ReturnAddress:           returnAddr.Address,
+		TimeoutHeight:           timeoutHeight.RevisionHeight,
+		TimeoutTime:             timeoutTime,
+		SourceChannel:           channelID,
+	}
+
+	if err := replaceOutputWithWithdrawal(resp.Plan, returnAddr.Address, withdrawal); err != nil {
+		return ibc.Tx{}, err
+	}
+
+	_, txResp, err := p.authorizeAndBroadcast(ctx, channel, resp.Plan)
+	if err != nil {
+		return ibc.Tx{}, err
+	}
+
+	// Penumbra has no gas, so the fee paid is reported instead, see PenumbraChain.GetGasFeesInNativeDenom.
+	fee := feeAmount(resp.Plan.GetFee())
+	if !fee.IsInt64() {
+		return ibc.Tx{}, fmt.Errorf("fee %s of ics20 withdrawal does not fit in an int64", fee)
+	}
+
+	return ibc.Tx{
+		Height:   txResp.DetectionHeight,
+		TxHash:   strings.ToUpper(hex.EncodeToString(txResp.Id.Hash)),
+		GasSpent: fee.Int64(),
+	}, nil
+}
+
+// defaultTransferTimeout is the amount of time after which an ICS-20 withdrawal times out when the caller does not
+// specify a timeout. It matches the default used by ibc-go's transfer CLI.
+const defaultTransferTimeout = 10 * time.Minute
+
+// ibcTransferTimeouts returns the timeout height and timestamp (in nanoseconds) to use for an ICS-20 withdrawal.
+// As with the transfer CLI of ibc-go, the timeouts in options are relative: the timeout height is added to
+// counterpartyHeight, the latest height of the channel's counterparty client, and the timeout timestamp to now.
+// If no timeout is specified, the withdrawal times out defaultTransferTimeout from now.
+func ibcTransferTimeouts(options ibc.TransferOptions, counterpartyHeight clienttypes.Height, now time.Time) (clienttypes.Height, uint64) {
+	if options.Timeout == nil || (options.Timeout.Height == 0 && options.Timeout.NanoSeconds == 0) {
+		return clienttypes.ZeroHeight(), uint64(now.Add(defaultTransferTimeout).UnixNano())
+	}
+
+	height := clienttypes.ZeroHeight()
+	if options.Timeout.Height != 0 {
+		height = clienttypes.NewHeight(counterpartyHeight.RevisionNumber, counterpartyHeight.RevisionHeight+options.Timeout.Height)
+	}
+
+	var timestamp uint64
+	if options.Timeout.NanoSeconds != 0 {
+		timestamp = uint64(now.UnixNano()) + options.Timeout.NanoSeconds
+	}
+
+	return height, timestamp
+}
+
+// counterpartyHeight returns the latest height of the counterparty chain tracked by the client of the transfer channel.
+func (p *PenumbraClientNode) counterpartyHeight(ctx context.Context, channelID string) (clienttypes.Height, error) {
+	conn, err := grpc.Dial(p.Chain.GetHostGRPCAddress(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return clienttypes.ZeroHeight(), err
+	}
+	defer conn.Close()
+
+	res, err := chanTypes.NewQueryClient(conn).ChannelClientState(ctx, &chanTypes.QueryChannelClientStateRequest{
+		PortId:    "transfer",
+		ChannelId: channelID,
+	})
+	if err != nil {
+		return clienttypes.ZeroHeight(), fmt.Errorf("failed to query client state of channel %s: %w", channelID, err)
+	}
+
+	var clientState ibctm.ClientState
+	if err := clientState.Unmarshal(res.GetIdentifiedClientState().GetClientState().GetValue()); err != nil {
+		return clienttypes.ZeroHeight(), fmt.Errorf("failed to decode client state of channel %s: %w", channelID, err)
+	}
+
+	return clientState.LatestHeight, nil
+}
+
+// replaceOutputWithWithdrawal swaps the output action sending funds to returnAddr in plan for an ICS-20 withdrawal
+// of the same value.
+func replaceOutputWithWithdrawal(plan *transactionv1alpha1.TransactionPlan, returnAddr *cryptov1alpha1.Address, withdrawal *ibcv1alpha1.Ics20Withdrawal) error {
+	if plan == nil {
+		return fmt.Errorf("transaction planner returned an empty plan")
+	}
+
+	for i, action := range plan.Actions {
+		output := action.GetOutput()
+		if output == nil {
+			continue
+		}
+		if !bytes.Equal(output.GetDestAddress().GetInner(), returnAddr.GetInner()) {
+			continue
+		}
+		if output.GetValue().GetAmount().GetHi() != withdrawal.Amount.Hi || output.GetValue().GetAmount().GetLo() != withdrawal.Amount.Lo {
+			continue
+		}
+
+		plan.Actions[i] = &transactionv1alpha1.ActionPlan{
+			Action: &transactionv1alpha1.ActionPlan_Withdrawal{Withdrawal: withdrawal},
+		}
+		return nil
+	}
+
+	return fmt.Errorf("transaction plan does not contain an output to the withdrawal return address")
 }
 
+// GetBalance returns the balance of the specified denom in the client's default account.
 func (p *PenumbraClientNode) GetBalance(ctx context.Context, denom string) (math.Int, error) {
+	return p.GetBalanceByAccount(ctx, 0, denom)
+}
+
+// GetBalanceByAccount returns the balance of the specified denom in the specified account of the client's spend key.
+func (p *PenumbraClientNode) GetBalanceByAccount(ctx context.Context, account uint32, denom string) (math.Int, error) {
 	channel, err := grpc.Dial(
 		p.hostGRPCPort,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
@@ -221,22 +496,102 @@ func (p *PenumbraClientNode) GetBalance(ctx context.Context, denom string) (math
 	}
 	defer channel.Close()
 
+	balances, err := p.balances(ctx, channel, account, &cryptov1alpha1.AssetId{AltBaseDenom: denom})
+	if err != nil {
+		return math.Int{}, err
+	}
+
+	if len(balances) <= 0 {
+		return math.Int{}, fmt.Errorf("no balance was found for the denom %s", denom)
+	}
+
+	return translateHiAndLo(balances[0].Balance.Amount.Hi, balances[0].Balance.Amount.Lo), nil
+}
+
+// GetAllBalances returns the balances of every asset held in the specified account of the client's spend key,
+// keyed by the base denom of each asset, or by the bech32m encoded asset ID for assets without denom metadata.
+func (p *PenumbraClientNode) GetAllBalances(ctx context.Context, account uint32) (map[string]math.Int, error) {
+	channel, err := grpc.Dial(
+		p.hostGRPCPort,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer channel.Close()
+
+	balances, err := p.balances(ctx, channel, account, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	queryClient := clientv1alpha1.NewSpecificQueryServiceClient(channel)
+
+	return mergeBalances(balances, func(assetID *cryptov1alpha1.AssetId) (*cryptov1alpha1.DenomMetadata, error) {
+		resp, err := queryClient.DenomMetadataById(ctx, &clientv1alpha1.DenomMetadataByIdRequest{
+			ChainId: p.Chain.Config().ChainID,
+			AssetId: assetID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get denom metadata for asset %x: %w", assetID.GetInner(), err)
+		}
+		return resp.GetDenomMetadata(), nil
+	})
+}
+
+// mergeBalances sums the balances per asset, keyed as described by GetAllBalances.
+// denomMetadata returns the denom metadata of an asset, or nil if the chain has none for it.
+func mergeBalances(
+	balances []*viewv1alpha1.BalancesResponse,
+	denomMetadata func(*cryptov1alpha1.AssetId) (*cryptov1alpha1.DenomMetadata, error),
+) (map[string]math.Int, error) {
+	allBalances := make(map[string]math.Int, len(balances))
+	for _, balance := range balances {
+		assetID := balance.GetBalance().GetAssetId()
+		metadata, err := denomMetadata(assetID)
+		if err != nil {
+			return nil, err
+		}
+
+		key := metadata.GetBase()
+		if key == "" {
+			key, err = encodeAssetID(assetID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		amount := translateHiAndLo(balance.GetBalance().GetAmount().GetHi(), balance.GetBalance().GetAmount().GetLo())
+		if existing, ok := allBalances[key]; ok {
+			amount = amount.Add(existing)
+		}
+		allBalances[key] = amount
+	}
+
+	return allBalances, nil
+}
+
+// balances streams the balances of the specified account from the view service, optionally filtered to one asset.
+func (p *PenumbraClientNode) balances(
+	ctx context.Context,
+	channel *grpc.ClientConn,
+	account uint32,
+	assetID *cryptov1alpha1.AssetId,
+) ([]*viewv1alpha1.BalancesResponse, error) {
 	viewClient := viewv1alpha1.NewViewProtocolServiceClient(channel)
 
 	balanceRequest := &viewv1alpha1.BalancesRequest{
 		AccountFilter: &cryptov1alpha1.AddressIndex{
-			Account: 0,
-		},
-		AssetIdFilter: &cryptov1alpha1.AssetId{
-			AltBaseDenom: denom,
+			Account: account,
 		},
+		AssetIdFilter: assetID,
 	}
 
 	// The BalanceByAddress method returns a stream response, containing
 	// zero-or-more balances, including denom and amount info per balance.
 	balanceStream, err := viewClient.Balances(ctx, balanceRequest)
 	if err != nil {
-		return math.Int{}, err
+		return nil, err
 	}
 
 	var balances []*viewv1alpha1.BalancesResponse
@@ -247,17 +602,13 @@ func (p *PenumbraClientNode) GetBalance(ctx context.Context, denom string) (math
 			if err == io.EOF {
 				break
 			} else {
-				return math.Int{}, err
+				return nil, err
 			}
 		}
 		balances = append(balances, balance)
 	}
 
-	if len(balances) <= 0 {
-		return math.Int{}, fmt.Errorf("no balance was found for the denom %s", denom)
-	}
-
-	return translateHiAndLo(balances[0].Balance.Amount.Hi, balances[0].Balance.Amount.Lo), nil
+	return balances, nil
 }
 
 // translateHiAndLo takes the high and low order bytes and decodes the two uint64 values into the single int128 value
@@ -300,6 +651,12 @@ func translateBigInt(i math.Int) (uint64, uint64) {
 	return hi, lo
 }
 
+// newAmount converts a Cosmos SDK Int into a Penumbra Amount.
+func newAmount(i math.Int) *cryptov1alpha1.Amount {
+	hi, lo := translateBigInt(i)
+	return &cryptov1alpha1.Amount{Hi: hi, Lo: lo}
+}
+
 // GetDenomMetadata invokes a gRPC request to obtain the DenomMetadata for a specified asset ID.
 func (p *PenumbraClientNode) GetDenomMetadata(ctx context.Context, assetId *cryptov1alpha1.AssetId) (*cryptov1alpha1.DenomMetadata, error) {
 	channel, err := grpc.Dial(
@@ -351,17 +708,22 @@ func (p *PenumbraClientNode) Initialize(ctx context.Context, spendKey, fullViewi
 }
 
 func (p *PenumbraClientNode) CreateNodeContainer(ctx context.Context, pdAddress string) error {
-	cmd := []string{
+	p.pdAddress = pdAddress
+
+	var env []string
+
+	return p.containerLifecycle.CreateContainer(ctx, p.TestName, p.NetworkID, p.Image, pclientdPorts, p.Bind(), p.HostName(), p.startCmd(pdAddress), env)
+}
+
+// startCmd returns the command of the pclientd container, connected to the pd instance at pdAddress.
+func (p *PenumbraClientNode) startCmd(pdAddress string) []string {
+	return []string{
 		"pclientd",
 		"--home", p.HomeDir(),
 		"--node", pdAddress,
 		"start",
 		"--bind-addr", "0.0.0.0:" + strings.Split(pclientdPort, "/")[0],
 	}
-
-	var env []string
-
-	return p.containerLifecycle.CreateContainer(ctx, p.TestName, p.NetworkID, p.Image, pclientdPorts, p.Bind(), p.HostName(), cmd, env)
 }
 
 func (p *PenumbraClientNode) StopContainer(ctx context.Context) error {
@@ -383,6 +745,102 @@ func (p *PenumbraClientNode) StartContainer(ctx context.Context) error {
 	return nil
 }
 
+func (p *PenumbraClientNode) RemoveContainer(ctx context.Context) error {
+	return p.containerLifecycle.RemoveContainer(ctx)
+}
+
+// Restart stops pclientd and starts it again connected to the pd instance at pdAddress,
+// keeping its synchronized state. If pdAddress is empty, the previous pd instance is used.
+func (p *PenumbraClientNode) Restart(ctx context.Context, pdAddress string) error {
+	return p.recreateContainer(ctx, pdAddress, false)
+}
+
+// ResyncFromGenesis stops pclientd, wipes its synchronized state and starts it again connected to the pd instance
+// at pdAddress, so that the view service rescans the chain from genesis. If pdAddress is empty, the previous pd
+// instance is used. The keys are kept, so this simulates recovering a wallet from its keys.
+func (p *PenumbraClientNode) ResyncFromGenesis(ctx context.Context, pdAddress string) error {
+	return p.recreateContainer(ctx, pdAddress, true)
+}
+
+func (p *PenumbraClientNode) recreateContainer(ctx context.Context, pdAddress string, wipeState bool) error {
+	pdAddress = p.restartPdAddress(pdAddress)
+
+	if err := p.StopContainer(ctx); err != nil {
+		return fmt.Errorf("failed to stop pclientd: %w", err)
+	}
+
+	// The pd address is part of the container command, so the container must be recreated to change it.
+	if err := p.RemoveContainer(ctx); err != nil {
+		return err
+	}
+
+	if wipeState {
+		if _, _, err := p.Exec(ctx, p.wipeStateCmd(), nil); err != nil {
+			return fmt.Errorf("failed to wipe pclientd state: %w", err)
+		}
+	}
+
+	if err := p.CreateNodeContainer(ctx, pdAddress); err != nil {
+		return err
+	}
+
+	return p.StartContainer(ctx)
+}
+
+// restartPdAddress returns the address of the pd instance to connect to when recreating the container,
+// which is the previous one if pdAddress is empty.
+func (p *PenumbraClientNode) restartPdAddress(pdAddress string) string {
+	if pdAddress == "" {
+		return p.pdAddress
+	}
+	return pdAddress
+}
+
+// wipeStateCmd returns the command removing the view service state of pclientd, keeping its keys and config.
+func (p *PenumbraClientNode) wipeStateCmd() []string {
+	return []string{"rm", "-f", filepath.Join(p.HomeDir(), pclientdDatabase)}
+}
+
+// WaitForSync blocks until the view service has caught up with the chain and synchronized to at least the
+// current height of the chain, or until the context is done.
+func (p *PenumbraClientNode) WaitForSync(ctx context.Context) error {
+	height, err := p.Chain.Height(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get chain height: %w", err)
+	}
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		status, err := p.syncStatus(ctx)
+		// The view service may not be serving yet right after a restart, so errors are retried.
+		if err == nil && !status.CatchingUp && status.SyncHeight >= height {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return fmt.Errorf("pclientd did not sync to height %d: %w", height, err)
+			}
+			return fmt.Errorf("pclientd did not sync to height %d, synced to %d: %w", height, status.SyncHeight, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *PenumbraClientNode) syncStatus(ctx context.Context) (*viewv1alpha1.StatusResponse, error) {
+	channel, err := grpc.Dial(p.hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer channel.Close()
+
+	viewClient := viewv1alpha1.NewViewProtocolServiceClient(channel)
+	return viewClient.Status(ctx, &viewv1alpha1.StatusRequest{})
+}
+
 // Exec run a container for a specific job and block until the container exits
 func (p *PenumbraClientNode) Exec(ctx context.Context, cmd []string, env []string) ([]byte, []byte, error) {
 	job := dockerutil.NewImage(p.log, p.DockerClient, p.NetworkID, p.TestName, p.Image.Repository, p.Image.Version)
@@ -3,8 +3,15 @@ package penumbra
 import (
 	"math/big"
 	"testing"
+	"time"
 
 	"cosmossdk.io/math"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	cryptov1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/crypto/v1alpha1"
+	ibcv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/ibc/v1alpha1"
+	transactionv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/transaction/v1alpha1"
+	viewv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/view/v1alpha1"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
 	"github.com/stretchr/testify/require"
 )
 
@@ -21,3 +28,133 @@ func TestBigIntDecoding(t *testing.T) {
 	converted = translateHiAndLo(hi, lo)
 	require.True(t, converted.Equal(bInt))
 }
+
+func TestReplaceOutputWithWithdrawal(t *testing.T) {
+	returnAddr := &cryptov1alpha1.Address{Inner: []byte("return")}
+	changeAddr := &cryptov1alpha1.Address{Inner: []byte("change")}
+	amount := &cryptov1alpha1.Amount{Lo: 1_000}
+
+	plan := &transactionv1alpha1.TransactionPlan{
+		Actions: []*transactionv1alpha1.ActionPlan{
+			{Action: &transactionv1alpha1.ActionPlan_Spend{Spend: &transactionv1alpha1.SpendPlan{}}},
+			{Action: &transactionv1alpha1.ActionPlan_Output{Output: &transactionv1alpha1.OutputPlan{
+				Value:       &cryptov1alpha1.Value{Amount: &cryptov1alpha1.Amount{Lo: 500}},
+				DestAddress: changeAddr,
+			}}},
+			{Action: &transactionv1alpha1.ActionPlan_Output{Output: &transactionv1alpha1.OutputPlan{
+				Value:       &cryptov1alpha1.Value{Amount: amount},
+				DestAddress: returnAddr,
+			}}},
+		},
+	}
+
+	withdrawal := &ibcv1alpha1.Ics20Withdrawal{Amount: amount, SourceChannel: "channel-0"}
+	require.NoError(t, replaceOutputWithWithdrawal(plan, returnAddr, withdrawal))
+	require.Len(t, plan.Actions, 3)
+	require.NotNil(t, plan.Actions[1].GetOutput())
+	require.Equal(t, withdrawal, plan.Actions[2].GetWithdrawal())
+
+	// The output to the return address has been consumed, so a second replacement must fail.
+	require.Error(t, replaceOutputWithWithdrawal(plan, returnAddr, withdrawal))
+}
+
+func TestIBCTransferTimeouts(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	counterpartyHeight := clienttypes.NewHeight(1, 100)
+
+	// Without a timeout, the withdrawal times out after the default timeout.
+	height, timestamp := ibcTransferTimeouts(ibc.TransferOptions{}, counterpartyHeight, now)
+	require.True(t, height.IsZero())
+	require.Equal(t, uint64(now.Add(defaultTransferTimeout).UnixNano()), timestamp)
+
+	// The timeout height is relative to the latest height of the counterparty client, keeping its revision number.
+	height, timestamp = ibcTransferTimeouts(ibc.TransferOptions{
+		Timeout: &ibc.IBCTimeout{Height: 10},
+	}, counterpartyHeight, now)
+	require.Equal(t, clienttypes.NewHeight(1, 110), height)
+	require.Zero(t, timestamp)
+
+	// The timeout timestamp is relative to now.
+	height, timestamp = ibcTransferTimeouts(ibc.TransferOptions{
+		Timeout: &ibc.IBCTimeout{NanoSeconds: uint64(time.Second)},
+	}, counterpartyHeight, now)
+	require.True(t, height.IsZero())
+	require.Equal(t, uint64(now.Add(time.Second).UnixNano()), timestamp)
+
+	height, timestamp = ibcTransferTimeouts(ibc.TransferOptions{
+		Timeout: &ibc.IBCTimeout{Height: 5, NanoSeconds: uint64(time.Minute)},
+	}, counterpartyHeight, now)
+	require.Equal(t, clienttypes.NewHeight(1, 105), height)
+	require.Equal(t, uint64(now.Add(time.Minute).UnixNano()), timestamp)
+}
+
+func TestMergeBalances(t *testing.T) {
+	known := &cryptov1alpha1.AssetId{Inner: make([]byte, 32)}
+	unknown := &cryptov1alpha1.AssetId{Inner: bytes32(1)}
+	other := &cryptov1alpha1.AssetId{Inner: bytes32(2)}
+
+	balance := func(assetID *cryptov1alpha1.AssetId, amount uint64) *viewv1alpha1.BalancesResponse {
+		return &viewv1alpha1.BalancesResponse{Balance: &cryptov1alpha1.Value{
+			AssetId: assetID,
+			Amount:  &cryptov1alpha1.Amount{Lo: amount},
+		}}
+	}
+
+	balances, err := mergeBalances([]*viewv1alpha1.BalancesResponse{
+		balance(known, 1),
+		balance(unknown, 10),
+		balance(known, 2),
+		balance(other, 100),
+		balance(unknown, 20),
+	}, func(assetID *cryptov1alpha1.AssetId) (*cryptov1alpha1.DenomMetadata, error) {
+		if assetID == known {
+			return &cryptov1alpha1.DenomMetadata{Base: "upenumbra"}, nil
+		}
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	// Assets without metadata are keyed by their asset ID, so they are not merged with each other.
+	unknownKey, err := encodeAssetID(unknown)
+	require.NoError(t, err)
+	require.Equal(t, "passet1qyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqsr63xqg", unknownKey)
+	otherKey, err := encodeAssetID(other)
+	require.NoError(t, err)
+
+	want := map[string]math.Int{
+		"upenumbra": math.NewInt(3),
+		unknownKey:  math.NewInt(30),
+		otherKey:    math.NewInt(100),
+	}
+	require.Len(t, balances, len(want))
+	for key, amount := range want {
+		require.Truef(t, amount.Equal(balances[key]), "balance of %s: want %s, got %s", key, amount, balances[key])
+	}
+}
+
+func bytes32(b byte) []byte {
+	bz := make([]byte, 32)
+	for i := range bz {
+		bz[i] = b
+	}
+	return bz
+}
+
+func TestRecreateContainerArgs(t *testing.T) {
+	p := &PenumbraClientNode{pdAddress: "http://pd-0:8080"}
+
+	// Without a new pd address, pclientd reconnects to the previous pd instance.
+	require.Equal(t, "http://pd-0:8080", p.restartPdAddress(""))
+	require.Equal(t, "http://pd-1:8080", p.restartPdAddress("http://pd-1:8080"))
+
+	require.Equal(t, []string{
+		"pclientd",
+		"--home", "/home/pclientd",
+		"--node", "http://pd-1:8080",
+		"start",
+		"--bind-addr", "0.0.0.0:8081",
+	}, p.startCmd("http://pd-1:8080"))
+
+	// Only the view service database is removed, the keys in config.toml are kept.
+	require.Equal(t, []string{"rm", "-f", "/home/pclientd/pclientd-db.sqlite"}, p.wipeStateCmd())
+}
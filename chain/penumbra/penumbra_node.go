@@ -2,6 +2,7 @@ package penumbra
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"sync"
 
@@ -52,6 +53,25 @@ func NewPenumbraNode(
 	}, nil
 }
 
+// clientNode returns the pclientd instance associated with keyName, if one exists.
+func (p *PenumbraNode) clientNode(keyName string) (*PenumbraClientNode, bool) {
+	p.clientsMu.Lock()
+	defer p.clientsMu.Unlock()
+	clientNode, ok := p.PenumbraClientNodes[keyName]
+	return clientNode, ok
+}
+
+// clientNodes returns all pclientd instances of the node.
+func (p *PenumbraNode) clientNodes() []*PenumbraClientNode {
+	p.clientsMu.Lock()
+	defer p.clientsMu.Unlock()
+	clientNodes := make([]*PenumbraClientNode, 0, len(p.PenumbraClientNodes))
+	for _, clientNode := range p.PenumbraClientNodes {
+		clientNodes = append(clientNodes, clientNode)
+	}
+	return clientNodes
+}
+
 func (p *PenumbraNode) CreateClientNode(
 	ctx context.Context,
 	log *zap.Logger,
@@ -89,10 +109,7 @@ func (p *PenumbraNode) CreateClientNode(
 		return err
 	}
 
-	if err := clientNode.CreateNodeContainer(
-		ctx,
-		"tcp://"+p.PenumbraAppNode.HostName()+":"+strings.Split(grpcPort, "/")[0],
-	); err != nil {
+	if err := clientNode.CreateNodeContainer(ctx, p.pdAddress()); err != nil {
 		return err
 	}
 
@@ -102,3 +119,29 @@ func (p *PenumbraNode) CreateClientNode(
 
 	return nil
 }
+
+// RemoveClientNode stops and removes the pclientd instance associated with keyName.
+func (p *PenumbraNode) RemoveClientNode(ctx context.Context, keyName string) error {
+	clientNode, ok := p.clientNode(keyName)
+	if !ok {
+		return fmt.Errorf("no pclientd instance found for key %s", keyName)
+	}
+
+	if err := clientNode.StopContainer(ctx); err != nil {
+		return fmt.Errorf("failed to stop pclientd: %w", err)
+	}
+	if err := clientNode.RemoveContainer(ctx); err != nil {
+		return err
+	}
+
+	p.clientsMu.Lock()
+	delete(p.PenumbraClientNodes, keyName)
+	p.clientsMu.Unlock()
+
+	return nil
+}
+
+// pdAddress returns the address pclientd instances use to connect to this node's pd instance.
+func (p *PenumbraNode) pdAddress() string {
+	return "tcp://" + p.PenumbraAppNode.HostName() + ":" + strings.Split(grpcPort, "/")[0]
+}
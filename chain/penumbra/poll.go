@@ -0,0 +1,31 @@
+package penumbra
+
+import (
+	"context"
+	"fmt"
+
+	governancev1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/governance/v1alpha1"
+	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+)
+
+// PollForProposalState attempts to find a proposal with matching ID and status, one of the ProposalStatus constants.
+func PollForProposalState(
+	ctx context.Context,
+	chain *PenumbraChain,
+	startHeight, maxHeight uint64,
+	proposalID uint64,
+	status string,
+) (*governancev1alpha1.ProposalState, error) {
+	doPoll := func(ctx context.Context, height uint64) (*governancev1alpha1.ProposalState, error) {
+		state, err := chain.ProposalState(ctx, proposalID)
+		if err != nil {
+			return nil, err
+		}
+		if s := ProposalStatus(state); s != status {
+			return nil, fmt.Errorf("proposal status (%s) does not match expected: (%s)", s, status)
+		}
+		return state, nil
+	}
+	bp := testutil.BlockPoller[*governancev1alpha1.ProposalState]{CurrentHeight: chain.Height, PollFunc: doPoll}
+	return bp.DoPoll(ctx, startHeight, maxHeight)
+}
@@ -0,0 +1,175 @@
+package penumbra
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/gogoproto/proto"
+	chanTypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	transactionv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/transaction/v1alpha1"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/internal/blockdb"
+	"golang.org/x/sync/errgroup"
+)
+
+type blockClient interface {
+	Block(ctx context.Context, height *int64) (*coretypes.ResultBlock, error)
+	BlockResults(ctx context.Context, height *int64) (*coretypes.ResultBlockResults, error)
+}
+
+// ibcMsgTypes are the IBC messages carried in Penumbra IBC actions that rangeBlockIbcMessages decodes.
+var ibcMsgTypes = map[string]func() proto.Message{
+	"/" + proto.MessageName(&chanTypes.MsgAcknowledgement{}): func() proto.Message { return &chanTypes.MsgAcknowledgement{} },
+	"/" + proto.MessageName(&chanTypes.MsgTimeout{}):         func() proto.Message { return &chanTypes.MsgTimeout{} },
+}
+
+// rangeBlockIbcMessages iterates through the IBC actions of all successful transactions in a block,
+// yielding each decoded IBC message to f. Actions carrying message types other than those in ibcMsgTypes are skipped.
+// Return true from f to stop iteration.
+//
+// Penumbra emits the standard IBC events when processing these messages, but the events do not carry the full packet
+// (timeout_packet omits the packet data and acknowledge_packet omits the acknowledgement), so the messages themselves
+// are decoded, while the block results are used to skip transactions that failed.
+func rangeBlockIbcMessages(ctx context.Context, client blockClient, height uint64, done func(proto.Message) bool) error {
+	h := int64(height)
+	block, err := client.Block(ctx, &h)
+	if err != nil {
+		return fmt.Errorf("tendermint rpc get block: %w", err)
+	}
+	blockRes, err := client.BlockResults(ctx, &h)
+	if err != nil {
+		return fmt.Errorf("tendermint rpc get block results: %w", err)
+	}
+
+	for i, txbz := range block.Block.Txs {
+		if i < len(blockRes.TxsResults) && blockRes.TxsResults[i].Code != 0 {
+			continue
+		}
+
+		var tx transactionv1alpha1.Transaction
+		if err := proto.Unmarshal(txbz, &tx); err != nil {
+			return fmt.Errorf("decode penumbra tx: %w", err)
+		}
+
+		for _, action := range tx.GetBody().GetActions() {
+			rawAction := action.GetIbcAction().GetRawAction()
+			if rawAction == nil {
+				continue
+			}
+
+			msg, err := decodeIbcAction(rawAction)
+			if err != nil {
+				return err
+			}
+			if msg == nil {
+				continue
+			}
+
+			if ok := done(msg); ok {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// decodeIbcAction decodes the IBC message wrapped in a raw IBC action.
+// Returns a nil message if the type is not one of ibcMsgTypes.
+func decodeIbcAction(rawAction *codectypes.Any) (proto.Message, error) {
+	newMsg, ok := ibcMsgTypes[rawAction.TypeUrl]
+	if !ok {
+		return nil, nil
+	}
+
+	msg := newMsg()
+	if err := proto.Unmarshal(rawAction.Value, msg); err != nil {
+		return nil, fmt.Errorf("decode ibc action %s: %w", rawAction.TypeUrl, err)
+	}
+	return msg, nil
+}
+
+// ibcPacket converts an ibc-go channel packet into an ibc.Packet.
+func ibcPacket(packet chanTypes.Packet) ibc.Packet {
+	return ibc.Packet{
+		Sequence:         packet.Sequence,
+		SourcePort:       packet.SourcePort,
+		SourceChannel:    packet.SourceChannel,
+		DestPort:         packet.DestinationPort,
+		DestChannel:      packet.DestinationChannel,
+		Data:             packet.Data,
+		TimeoutHeight:    packet.TimeoutHeight.String(),
+		TimeoutTimestamp: ibc.Nanoseconds(packet.TimeoutTimestamp),
+	}
+}
+
+// findTxs returns the transactions of a block with their events, for storage in the block database.
+// Transactions are stored as the JSON encoding of the decoded Penumbra transaction, falling back to the hex
+// encoded transaction bytes if decoding fails. Events emitted outside of transactions are stored in an
+// additional artificial transaction.
+func findTxs(ctx context.Context, client blockClient, height uint64) ([]blockdb.Tx, error) {
+	h := int64(height)
+	var eg errgroup.Group
+	var blockRes *coretypes.ResultBlockResults
+	var block *coretypes.ResultBlock
+	eg.Go(func() (err error) {
+		blockRes, err = client.BlockResults(ctx, &h)
+		return err
+	})
+	eg.Go(func() (err error) {
+		block, err = client.Block(ctx, &h)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	txs := make([]blockdb.Tx, 0, len(block.Block.Txs)+1)
+	for i, txbz := range block.Block.Txs {
+		var newTx blockdb.Tx
+		newTx.Data = []byte(fmt.Sprintf(`{"data":"%s"}`, hex.EncodeToString(txbz)))
+
+		var tx transactionv1alpha1.Transaction
+		if err := proto.Unmarshal(txbz, &tx); err == nil {
+			if b, err := json.Marshal(&tx); err == nil {
+				newTx.Data = b
+			}
+		}
+
+		if i < len(blockRes.TxsResults) {
+			newTx.Events = blockdbEvents(blockRes.TxsResults[i].Events)
+		}
+		txs = append(txs, newTx)
+	}
+
+	if len(blockRes.FinalizeBlockEvents) > 0 {
+		txs = append(txs, blockdb.Tx{
+			Data:   []byte(`{"data":"finalize_block","note":"this is a transaction artificially created for debugging purposes"}`),
+			Events: blockdbEvents(blockRes.FinalizeBlockEvents),
+		})
+	}
+
+	return txs, nil
+}
+
+func blockdbEvents(events []abcitypes.Event) []blockdb.Event {
+	dbEvents := make([]blockdb.Event, len(events))
+	for i, e := range events {
+		attrs := make([]blockdb.EventAttribute, len(e.Attributes))
+		for j, attr := range e.Attributes {
+			attrs[j] = blockdb.EventAttribute{
+				Key:   string(attr.Key),
+				Value: string(attr.Value),
+			}
+		}
+		dbEvents[i] = blockdb.Event{
+			Type:       e.Type,
+			Attributes: attrs,
+		}
+	}
+	return dbEvents
+}
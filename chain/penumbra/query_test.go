@@ -0,0 +1,120 @@
+package penumbra
+
+import (
+	"context"
+	"testing"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/gogoproto/proto"
+	chanTypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	ibcv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/ibc/v1alpha1"
+	transactionv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/transaction/v1alpha1"
+	"github.com/stretchr/testify/require"
+)
+
+type mockBlockClient struct {
+	txs            []tmtypes.Tx
+	results        []*abcitypes.ExecTxResult
+	finalizeEvents []abcitypes.Event
+}
+
+func (m mockBlockClient) Block(ctx context.Context, height *int64) (*coretypes.ResultBlock, error) {
+	return &coretypes.ResultBlock{Block: &tmtypes.Block{Data: tmtypes.Data{Txs: m.txs}}}, nil
+}
+
+func (m mockBlockClient) BlockResults(ctx context.Context, height *int64) (*coretypes.ResultBlockResults, error) {
+	return &coretypes.ResultBlockResults{TxsResults: m.results, FinalizeBlockEvents: m.finalizeEvents}, nil
+}
+
+func ibcActionTx(t *testing.T, msgs ...proto.Message) tmtypes.Tx {
+	t.Helper()
+
+	var actions []*transactionv1alpha1.Action
+	for _, msg := range msgs {
+		value, err := proto.Marshal(msg)
+		require.NoError(t, err)
+
+		actions = append(actions, &transactionv1alpha1.Action{
+			Action: &transactionv1alpha1.Action_IbcAction{IbcAction: &ibcv1alpha1.IbcAction{
+				RawAction: &codectypes.Any{TypeUrl: "/" + proto.MessageName(msg), Value: value},
+			}},
+		})
+	}
+
+	bz, err := proto.Marshal(&transactionv1alpha1.Transaction{
+		Body: &transactionv1alpha1.TransactionBody{Actions: actions},
+	})
+	require.NoError(t, err)
+	return bz
+}
+
+func TestRangeBlockIbcMessages(t *testing.T) {
+	packet := chanTypes.Packet{Sequence: 1, SourcePort: "transfer", SourceChannel: "channel-0", Data: []byte("data")}
+	ack := &chanTypes.MsgAcknowledgement{Packet: packet, Acknowledgement: []byte(`{"result":"AQ=="}`)}
+	timeout := &chanTypes.MsgTimeout{Packet: packet}
+	recv := &chanTypes.MsgRecvPacket{Packet: packet}
+
+	client := mockBlockClient{
+		txs: []tmtypes.Tx{
+			ibcActionTx(t, recv, ack),
+			ibcActionTx(t, timeout),
+			ibcActionTx(t, ack),
+		},
+		results: []*abcitypes.ExecTxResult{{Code: 0}, {Code: 0}, {Code: 1}},
+	}
+
+	var found []proto.Message
+	err := rangeBlockIbcMessages(context.Background(), client, 1, func(msg proto.Message) bool {
+		found = append(found, msg)
+		return false
+	})
+	require.NoError(t, err)
+
+	// The receive is not one of the decoded message types, and the last transaction failed.
+	require.Len(t, found, 2)
+	require.Equal(t, ack, found[0])
+	require.Equal(t, timeout, found[1])
+
+	ibcPkt := ibcPacket(found[0].(*chanTypes.MsgAcknowledgement).Packet)
+	require.Equal(t, uint64(1), ibcPkt.Sequence)
+	require.Equal(t, "channel-0", ibcPkt.SourceChannel)
+	require.Equal(t, "0-0", ibcPkt.TimeoutHeight)
+}
+
+func TestFindTxs(t *testing.T) {
+	packet := chanTypes.Packet{Sequence: 1, SourcePort: "transfer", SourceChannel: "channel-0"}
+
+	client := mockBlockClient{
+		txs: []tmtypes.Tx{
+			ibcActionTx(t, &chanTypes.MsgTimeout{Packet: packet}),
+			tmtypes.Tx("not a penumbra tx"),
+		},
+		results: []*abcitypes.ExecTxResult{
+			{Events: []abcitypes.Event{{
+				Type:       "timeout_packet",
+				Attributes: []abcitypes.EventAttribute{{Key: "packet_sequence", Value: "1"}},
+			}}},
+			{Code: 1},
+		},
+		finalizeEvents: []abcitypes.Event{{Type: "epoch"}},
+	}
+
+	txs, err := findTxs(context.Background(), client, 1)
+	require.NoError(t, err)
+	require.Len(t, txs, 3)
+
+	require.Contains(t, string(txs[0].Data), "raw_action")
+	require.Len(t, txs[0].Events, 1)
+	require.Equal(t, "timeout_packet", txs[0].Events[0].Type)
+	require.Equal(t, "packet_sequence", txs[0].Events[0].Attributes[0].Key)
+	require.Equal(t, "1", txs[0].Events[0].Attributes[0].Value)
+
+	require.Contains(t, string(txs[1].Data), `"data":"`)
+	require.Empty(t, txs[1].Events)
+
+	require.Contains(t, string(txs[2].Data), "finalize_block")
+	require.Equal(t, "epoch", txs[2].Events[0].Type)
+}
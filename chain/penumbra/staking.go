@@ -0,0 +1,281 @@
+package penumbra
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"cosmossdk.io/math"
+	clientv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/client/v1alpha1"
+	cryptov1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/crypto/v1alpha1"
+	stakev1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/stake/v1alpha1"
+	viewv1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/view/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// identityKeyPrefix is the human-readable part of a bech32m encoded validator identity key.
+const identityKeyPrefix = "penumbravalid"
+
+// EncodeIdentityKey returns the bech32m representation of a validator identity key,
+// as used in validator definitions and by pcli.
+func EncodeIdentityKey(identityKey *cryptov1alpha1.IdentityKey) (string, error) {
+	return encodeBech32m(identityKeyPrefix, identityKey.GetIk())
+}
+
+// DelegationDenom returns the denom of the delegation tokens for the validator with the given identity key.
+func DelegationDenom(identityKey *cryptov1alpha1.IdentityKey) (string, error) {
+	ik, err := EncodeIdentityKey(identityKey)
+	if err != nil {
+		return "", err
+	}
+	return "udelegation_" + ik, nil
+}
+
+// Delegate delegates amount of the staking token from the client's account to the validator with the given identity key,
+// at the validator's current exchange rate.
+func (p *PenumbraClientNode) Delegate(ctx context.Context, identityKey *cryptov1alpha1.IdentityKey, amount math.Int, opts ...TxOption) error {
+	channel, err := grpc.Dial(p.hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer channel.Close()
+
+	rateData, err := currentValidatorRate(ctx, channel, p.Chain.Config().ChainID, identityKey)
+	if err != nil {
+		return err
+	}
+
+	tpr := &viewv1alpha1.TransactionPlannerRequest{
+		Delegations: []*viewv1alpha1.TransactionPlannerRequest_Delegate{{
+			Amount:   newAmount(amount),
+			RateData: rateData,
+		}},
+	}
+	applyTxOptions(tpr, opts)
+
+	_, _, err = p.planAndBroadcast(ctx, channel, tpr)
+	return err
+}
+
+// Undelegate undelegates amount of delegation tokens for the validator with the given identity key.
+// The resulting unbonding tokens can be claimed for staking tokens once the unbonding period has passed,
+// see PenumbraChain.UndelegateClaim.
+func (p *PenumbraClientNode) Undelegate(ctx context.Context, identityKey *cryptov1alpha1.IdentityKey, amount math.Int, opts ...TxOption) error {
+	denom, err := DelegationDenom(identityKey)
+	if err != nil {
+		return err
+	}
+
+	channel, err := grpc.Dial(p.hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer channel.Close()
+
+	rateData, err := currentValidatorRate(ctx, channel, p.Chain.Config().ChainID, identityKey)
+	if err != nil {
+		return err
+	}
+
+	tpr := &viewv1alpha1.TransactionPlannerRequest{
+		Undelegations: []*viewv1alpha1.TransactionPlannerRequest_Undelegate{{
+			Value: &cryptov1alpha1.Value{
+				Amount:  newAmount(amount),
+				AssetId: &cryptov1alpha1.AssetId{AltBaseDenom: denom},
+			},
+			RateData: rateData,
+		}},
+	}
+	applyTxOptions(tpr, opts)
+
+	_, _, err = p.planAndBroadcast(ctx, channel, tpr)
+	return err
+}
+
+// DelegationTokenBalance returns the client's balance of delegation tokens for the validator with the given identity key.
+// Returns zero if the client holds no delegation tokens for the validator.
+func (p *PenumbraClientNode) DelegationTokenBalance(ctx context.Context, identityKey *cryptov1alpha1.IdentityKey) (math.Int, error) {
+	denom, err := DelegationDenom(identityKey)
+	if err != nil {
+		return math.Int{}, err
+	}
+
+	channel, err := grpc.Dial(p.hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return math.Int{}, err
+	}
+	defer channel.Close()
+
+	balances, err := p.balances(ctx, channel, 0, &cryptov1alpha1.AssetId{AltBaseDenom: denom})
+	if err != nil {
+		return math.Int{}, err
+	}
+
+	total := math.ZeroInt()
+	for _, balance := range balances {
+		amount := balance.GetBalance().GetAmount()
+		total = total.Add(translateHiAndLo(amount.GetHi(), amount.GetLo()))
+	}
+
+	return total, nil
+}
+
+// ValidatorSet returns the info of all active validators, including inactive validators if showInactive is true.
+func (p *PenumbraClientNode) ValidatorSet(ctx context.Context, showInactive bool) ([]*stakev1alpha1.ValidatorInfo, error) {
+	channel, err := grpc.Dial(p.hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer channel.Close()
+
+	return validatorSet(ctx, channel, p.Chain.Config().ChainID, showInactive)
+}
+
+// ValidatorStatus returns the current status of the validator with the given identity key.
+func (p *PenumbraClientNode) ValidatorStatus(ctx context.Context, identityKey *cryptov1alpha1.IdentityKey) (*stakev1alpha1.ValidatorStatus, error) {
+	channel, err := grpc.Dial(p.hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer channel.Close()
+
+	return validatorStatus(ctx, channel, p.Chain.Config().ChainID, identityKey)
+}
+
+// validatorSet queries the validator set from a pd or pclientd gRPC endpoint.
+func validatorSet(ctx context.Context, channel *grpc.ClientConn, chainID string, showInactive bool) ([]*stakev1alpha1.ValidatorInfo, error) {
+	queryClient := clientv1alpha1.NewObliviousQueryServiceClient(channel)
+
+	stream, err := queryClient.ValidatorInfo(ctx, &clientv1alpha1.ValidatorInfoRequest{
+		ChainId:      chainID,
+		ShowInactive: showInactive,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var validators []*stakev1alpha1.ValidatorInfo
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			// A gRPC streaming response will return EOF when it's done.
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		validators = append(validators, resp.ValidatorInfo)
+	}
+
+	return validators, nil
+}
+
+// validatorStatus queries the status of a validator from a pd or pclientd gRPC endpoint.
+func validatorStatus(
+	ctx context.Context,
+	channel *grpc.ClientConn,
+	chainID string,
+	identityKey *cryptov1alpha1.IdentityKey,
+) (*stakev1alpha1.ValidatorStatus, error) {
+	queryClient := clientv1alpha1.NewSpecificQueryServiceClient(channel)
+
+	resp, err := queryClient.ValidatorStatus(ctx, &clientv1alpha1.ValidatorStatusRequest{
+		ChainId:     chainID,
+		IdentityKey: identityKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query validator status: %w", err)
+	}
+
+	return resp.Status, nil
+}
+
+// currentValidatorRate queries the exchange rate of a validator's delegation tokens for the current epoch.
+func currentValidatorRate(
+	ctx context.Context,
+	channel *grpc.ClientConn,
+	chainID string,
+	identityKey *cryptov1alpha1.IdentityKey,
+) (*stakev1alpha1.RateData, error) {
+	queryClient := clientv1alpha1.NewSpecificQueryServiceClient(channel)
+
+	resp, err := queryClient.CurrentValidatorRate(ctx, &clientv1alpha1.CurrentValidatorRateRequest{
+		ChainId:     chainID,
+		IdentityKey: identityKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query validator rate: %w", err)
+	}
+	if resp.Data == nil {
+		return nil, fmt.Errorf("no rate data found for validator %x", identityKey.GetIk())
+	}
+
+	return resp.Data, nil
+}
+
+// Delegate delegates amount of the staking token from the account of keyName to the validator with the given identity key.
+func (c *PenumbraChain) Delegate(ctx context.Context, keyName string, identityKey *cryptov1alpha1.IdentityKey, amount math.Int, opts ...TxOption) error {
+	clientNode, err := c.clientNode(keyName)
+	if err != nil {
+		return err
+	}
+	return clientNode.Delegate(ctx, identityKey, amount, opts...)
+}
+
+// Undelegate undelegates amount of delegation tokens held by keyName for the validator with the given identity key.
+func (c *PenumbraChain) Undelegate(ctx context.Context, keyName string, identityKey *cryptov1alpha1.IdentityKey, amount math.Int, opts ...TxOption) error {
+	clientNode, err := c.clientNode(keyName)
+	if err != nil {
+		return err
+	}
+	return clientNode.Undelegate(ctx, identityKey, amount, opts...)
+}
+
+// UndelegateClaim claims the staking tokens of all undelegations made by keyName that have finished unbonding.
+func (c *PenumbraChain) UndelegateClaim(ctx context.Context, keyName string) error {
+	return c.getFullNode().PenumbraAppNode.UndelegateClaim(ctx, keyName)
+}
+
+// DelegationTokenBalance returns the balance of delegation tokens held by keyName for the validator with the given identity key.
+func (c *PenumbraChain) DelegationTokenBalance(ctx context.Context, keyName string, identityKey *cryptov1alpha1.IdentityKey) (math.Int, error) {
+	clientNode, err := c.clientNode(keyName)
+	if err != nil {
+		return math.Int{}, err
+	}
+	return clientNode.DelegationTokenBalance(ctx, identityKey)
+}
+
+// ValidatorDefinitionTemplate returns a new validator definition for the validator identity belonging to keyName.
+// See PenumbraAppNode.ValidatorDefinitionTemplate.
+func (c *PenumbraChain) ValidatorDefinitionTemplate(ctx context.Context, keyName string) (PenumbraValidatorDefinition, error) {
+	return c.getFullNode().PenumbraAppNode.ValidatorDefinitionTemplate(ctx, keyName)
+}
+
+// UploadValidatorDefinition declares a new validator or updates an existing one, signing the definition
+// with the identity key belonging to keyName. See PenumbraAppNode.UploadValidatorDefinition.
+func (c *PenumbraChain) UploadValidatorDefinition(ctx context.Context, keyName string, def PenumbraValidatorDefinition) error {
+	return c.getFullNode().PenumbraAppNode.UploadValidatorDefinition(ctx, keyName, def)
+}
+
+// ValidatorSet returns the info of all active validators, including inactive validators if showInactive is true.
+func (c *PenumbraChain) ValidatorSet(ctx context.Context, showInactive bool) ([]*stakev1alpha1.ValidatorInfo, error) {
+	channel, err := grpc.Dial(c.GetHostGRPCAddress(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer channel.Close()
+
+	return validatorSet(ctx, channel, c.cfg.ChainID, showInactive)
+}
+
+// ValidatorStatus returns the current status of the validator with the given identity key.
+func (c *PenumbraChain) ValidatorStatus(ctx context.Context, identityKey *cryptov1alpha1.IdentityKey) (*stakev1alpha1.ValidatorStatus, error) {
+	channel, err := grpc.Dial(c.GetHostGRPCAddress(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer channel.Close()
+
+	return validatorStatus(ctx, channel, c.cfg.ChainID, identityKey)
+}
@@ -0,0 +1,24 @@
+package penumbra
+
+import (
+	"testing"
+
+	cryptov1alpha1 "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/crypto/v1alpha1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeIdentityKey(t *testing.T) {
+	ik := make([]byte, 32)
+	for i := range ik {
+		ik[i] = byte(i)
+	}
+	identityKey := &cryptov1alpha1.IdentityKey{Ik: ik}
+
+	encoded, err := EncodeIdentityKey(identityKey)
+	require.NoError(t, err)
+	require.Equal(t, "penumbravalid1qqqsyqcyq5rqwzqfpg9scrgwpugpzysnzs23v9ccrydpk8qarc0s6mjyuz", encoded)
+
+	denom, err := DelegationDenom(identityKey)
+	require.NoError(t, err)
+	require.Equal(t, "udelegation_"+encoded, denom)
+}
@@ -0,0 +1,242 @@
+package penumbra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v8/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// Upgrade performs a chain upgrade to the pd image containerRepo:version.
+// Once the chain reaches haltHeight every node is halted, the new pd release migrates the state of each node
+// and all pd, CometBFT and pclientd containers are restarted, pd and pclientd on the new image.
+// Returns once the chain produces blocks again.
+//
+// pd has no halt height of its own, so the nodes are halted as soon as haltHeight is observed and the chain may
+// stop a block or two later. Upgrade fails if the nodes did not all halt at the same height.
+func (c *PenumbraChain) Upgrade(ctx context.Context, cli *client.Client, haltHeight uint64, containerRepo, version string) error {
+	height, err := c.HaltAllNodes(ctx, haltHeight)
+	if err != nil {
+		return err
+	}
+
+	c.log.Info("Upgrading halted chain",
+		zap.String("chain_id", c.cfg.ChainID),
+		zap.Uint64("height", height),
+		zap.String("repository", containerRepo),
+		zap.String("tag", version),
+	)
+
+	if err := c.StopAllNodes(ctx); err != nil {
+		return fmt.Errorf("failed to stop nodes: %w", err)
+	}
+
+	c.UpgradeVersion(ctx, cli, containerRepo, version)
+
+	if err := c.MigrateAllNodes(ctx); err != nil {
+		return err
+	}
+
+	if err := c.StartAllNodes(ctx); err != nil {
+		return fmt.Errorf("failed to start nodes after upgrade: %w", err)
+	}
+
+	if err := testutil.WaitForBlocks(ctx, 2, c); err != nil {
+		return fmt.Errorf("chain did not produce blocks after upgrade: %w", err)
+	}
+
+	return c.RestartAllClientNodes(ctx)
+}
+
+// HaltAllNodes waits for the chain to reach haltHeight and halts block production by stopping the CometBFT
+// instance of every node, followed by pd. Returns the height of the last block committed by pd.
+// Returns an error if the pd instances did not all commit the same last block, in which case pd is left running
+// and CometBFT is restarted, so that the chain is not left halted.
+func (c *PenumbraChain) HaltAllNodes(ctx context.Context, haltHeight uint64) (uint64, error) {
+	if err := c.waitForHeight(ctx, haltHeight); err != nil {
+		return 0, err
+	}
+
+	var eg errgroup.Group
+	for _, n := range c.PenumbraNodes {
+		n := n
+		eg.Go(func() error {
+			return n.TendermintNode.StopContainer(ctx)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return 0, fmt.Errorf("failed to stop tendermint: %w", err)
+	}
+
+	height, err := c.lastCommittedHeight(ctx)
+	if err != nil {
+		var startEg errgroup.Group
+		for _, n := range c.PenumbraNodes {
+			n := n
+			startEg.Go(func() error {
+				return n.TendermintNode.StartContainer(ctx)
+			})
+		}
+		if startErr := startEg.Wait(); startErr != nil {
+			err = multierr.Append(err, fmt.Errorf("failed to restart tendermint: %w", startErr))
+		}
+		return 0, err
+	}
+
+	var pdEg errgroup.Group
+	for _, n := range c.PenumbraNodes {
+		n := n
+		pdEg.Go(func() error {
+			return n.PenumbraAppNode.StopContainer(ctx)
+		})
+	}
+	if err := pdEg.Wait(); err != nil {
+		return 0, fmt.Errorf("failed to stop pd: %w", err)
+	}
+
+	return height, nil
+}
+
+// lastCommittedHeight returns the height of the last block committed by the pd instances,
+// which must all have committed the same last block.
+func (c *PenumbraChain) lastCommittedHeight(ctx context.Context) (uint64, error) {
+	heights := make([]uint64, len(c.PenumbraNodes))
+	for i, n := range c.PenumbraNodes {
+		info, err := pdInfo(ctx, n.PenumbraAppNode.hostGRPCPort)
+		if err != nil {
+			return 0, err
+		}
+		heights[i] = info.LastBlockHeight
+	}
+	return sameHeight(heights)
+}
+
+// sameHeight returns the height shared by all nodes, or an error if they are at different heights.
+func sameHeight(heights []uint64) (uint64, error) {
+	if len(heights) == 0 {
+		return 0, fmt.Errorf("no node heights")
+	}
+	for i, h := range heights {
+		if h != heights[0] {
+			return 0, fmt.Errorf("nodes halted at different heights: node 0 at %d, node %d at %d", heights[0], i, h)
+		}
+	}
+	return heights[0], nil
+}
+
+// StopAllNodes stops and removes the pd and CometBFT containers of every node.
+// The node volumes are kept, so the nodes can be started again with StartAllNodes.
+func (c *PenumbraChain) StopAllNodes(ctx context.Context) error {
+	var eg errgroup.Group
+	for _, n := range c.PenumbraNodes {
+		n := n
+		eg.Go(func() error {
+			if err := n.TendermintNode.StopContainer(ctx); err != nil {
+				return err
+			}
+			return n.TendermintNode.RemoveContainer(ctx)
+		})
+		eg.Go(func() error {
+			if err := n.PenumbraAppNode.StopContainer(ctx); err != nil {
+				return err
+			}
+			return n.PenumbraAppNode.RemoveContainer(ctx)
+		})
+	}
+	return eg.Wait()
+}
+
+// StartAllNodes creates and starts new pd and CometBFT containers for every node, using the current images.
+// Should only be used if the chain has previously been started with Start.
+func (c *PenumbraChain) StartAllNodes(ctx context.Context) error {
+	if err := c.createNodeContainers(ctx); err != nil {
+		return err
+	}
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, n := range c.PenumbraNodes {
+		n := n
+		eg.Go(func() error {
+			return n.TendermintNode.StartContainer(egCtx)
+		})
+		eg.Go(func() error {
+			return n.PenumbraAppNode.StartContainer(egCtx)
+		})
+	}
+	return eg.Wait()
+}
+
+// UpgradeVersion sets the pd image of every node and pclientd instance to containerRepo:version and pulls it.
+// The running containers are unaffected until they are recreated, e.g. with StopAllNodes and StartAllNodes.
+func (c *PenumbraChain) UpgradeVersion(ctx context.Context, cli *client.Client, containerRepo, version string) {
+	c.cfg.Images[1].Repository = containerRepo
+	c.cfg.Images[1].Version = version
+	for _, n := range c.PenumbraNodes {
+		n.PenumbraAppNode.Image.Repository = containerRepo
+		n.PenumbraAppNode.Image.Version = version
+
+		for _, clientNode := range n.clientNodes() {
+			clientNode.Image.Repository = containerRepo
+			clientNode.Image.Version = version
+		}
+	}
+	c.pullImages(ctx, cli)
+}
+
+// MigrateAllNodes runs the state migration of the current pd image against every node. The nodes must be stopped.
+func (c *PenumbraChain) MigrateAllNodes(ctx context.Context) error {
+	var eg errgroup.Group
+	for _, n := range c.PenumbraNodes {
+		n := n
+		eg.Go(func() error {
+			return n.PenumbraAppNode.Migrate(ctx, n.TendermintNode.VolumeName, n.TendermintNode.HomeDir())
+		})
+	}
+	return eg.Wait()
+}
+
+// RestartAllClientNodes recreates every pclientd instance of the chain with its current image,
+// keeping the synchronized state of each client.
+func (c *PenumbraChain) RestartAllClientNodes(ctx context.Context) error {
+	var eg errgroup.Group
+	for _, n := range c.PenumbraNodes {
+		for _, clientNode := range n.clientNodes() {
+			clientNode := clientNode
+			eg.Go(func() error {
+				return clientNode.Restart(ctx, "")
+			})
+		}
+	}
+	return eg.Wait()
+}
+
+// Migrate runs pd's migration of the node's state to the version of the node's image. pd must be stopped.
+// The CometBFT home directory of the node, found in cometVolume at cometHome, is migrated along with it,
+// since the migration resets CometBFT to start from the migrated state.
+func (p *PenumbraAppNode) Migrate(ctx context.Context, cometVolume, cometHome string) error {
+	job := dockerutil.NewImage(p.log, p.DockerClient, p.NetworkID, p.TestName, p.Image.Repository, p.Image.Version)
+	opts := dockerutil.ContainerOptions{
+		Binds: append(p.Bind(), cometVolume+":"+cometHome),
+		User:  p.Image.UidGid,
+	}
+
+	res := job.Run(ctx, p.migrateCmd(cometHome), opts)
+	if res.Err != nil {
+		return fmt.Errorf("failed to migrate pd state: %w", res.Err)
+	}
+	return nil
+}
+
+// migrateCmd returns the pd command migrating the node's state, along with the CometBFT home directory at cometHome.
+func (p *PenumbraAppNode) migrateCmd(cometHome string) []string {
+	return []string{
+		"pd", "migrate",
+		"--home", p.HomeDir(),
+		"--comet-home", cometHome,
+	}
+}
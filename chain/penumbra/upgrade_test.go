@@ -0,0 +1,29 @@
+package penumbra
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSameHeight(t *testing.T) {
+	height, err := sameHeight([]uint64{42, 42, 42})
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), height)
+
+	_, err = sameHeight([]uint64{42, 43, 42})
+	require.Error(t, err)
+
+	_, err = sameHeight(nil)
+	require.Error(t, err)
+}
+
+func TestMigrateCmd(t *testing.T) {
+	p := &PenumbraAppNode{}
+
+	require.Equal(t, []string{
+		"pd", "migrate",
+		"--home", "/home/heighliner",
+		"--comet-home", "/var/tendermint/penumbra",
+	}, p.migrateCmd("/var/tendermint/penumbra"))
+}
@@ -7,9 +7,6 @@ import (
 	"strings"
 	"sync"
 
-	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
-	"github.com/strangelove-ventures/interchaintest/v8/chain/penumbra"
-	"github.com/strangelove-ventures/interchaintest/v8/chain/polkadot"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
 	"go.uber.org/zap"
 	"gopkg.in/yaml.v3"
@@ -128,34 +125,15 @@ func buildChain(log *zap.Logger, testName string, cfg ibc.ChainConfig, numValida
 		nf = *numFullNodes
 	}
 
-	switch cfg.Type {
-	case "cosmos":
-		return cosmos.NewCosmosChain(testName, cfg, nv, nf, log), nil
-	case "penumbra":
-		return penumbra.NewPenumbraChain(log, testName, cfg, nv, nf), nil
-	case "polkadot":
-		// TODO Clean this up. RelayChain config should only reference cfg.Images[0] and parachains should iterate through the remaining
-		// Maybe just pass everything in like NewCosmosChain and NewPenumbraChain, let NewPolkadotChain figure it out
-		// Or parachains and ICS consumer chains maybe should be their own chain
-		switch {
-		case strings.Contains(cfg.Name, "composable"):
-			parachains := []polkadot.ParachainConfig{{
-				//Bin:             "composable",
-				Bin:     "parachain-node",
-				ChainID: "dev-2000",
-				//ChainID:         "dali-dev",
-				Image:           cfg.Images[1],
-				NumNodes:        nf,
-				Flags:           []string{"--execution=wasm", "--wasmtime-instantiation-strategy=recreate-instance-copy-on-write"},
-				RelayChainFlags: []string{"--execution=wasm"},
-			}}
-			return polkadot.NewPolkadotChain(log, testName, cfg, nv, parachains), nil
-		default:
-			return nil, fmt.Errorf("unexpected error, unknown polkadot parachain: %s", cfg.Name)
-		}
-	default:
-		return nil, fmt.Errorf("unexpected error, unknown chain type: %s for chain: %s", cfg.Type, cfg.Name)
+	constructor, ok := chainConstructor(cfg.Type)
+	if !ok {
+		return nil, fmt.Errorf(
+			"unexpected error, unknown chain type: %s for chain: %s (registered types are: %s)",
+			cfg.Type, cfg.Name, strings.Join(RegisteredChainTypes(), ", "),
+		)
 	}
+
+	return constructor(log, testName, cfg, nv, nf)
 }
 
 func (f *BuiltinChainFactory) Name() string {
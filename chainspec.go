@@ -18,32 +18,47 @@ import (
 type ChainSpec struct {
 	// Name is the name of the built-in config to use as a basis for this chain spec.
 	// Required unless every other field is set.
-	Name string
+	Name string `yaml:"name"`
 
 	// ChainName sets the Name of the embedded ibc.ChainConfig, i.e. the name of the chain.
-	ChainName string
+	ChainName string `yaml:"chain-name"`
 
 	// Version of the docker image to use.
 	// Must be set.
-	Version string
+	Version string `yaml:"version"`
 
 	// NoHostMount is a pointers in ChainSpec
 	// so zero-overrides can be detected from omitted overrides.
-	NoHostMount *bool
+	NoHostMount *bool `yaml:"no-host-mount"`
 
 	// Embedded ChainConfig to allow for simple JSON definition of a ChainSpec.
-	ibc.ChainConfig
+	// In YAML, it is nested under chain-config since its name and no-host-mount fields clash with those of the ChainSpec.
+	ibc.ChainConfig `yaml:"chain-config"`
 
 	// How many validators and how many full nodes to use
 	// when instantiating the chain.
 	// If unspecified, NumValidators defaults to 2 and NumFullNodes defaults to 1.
-	NumValidators, NumFullNodes *int
+	NumValidators *int `yaml:"num-validators"`
+	NumFullNodes  *int `yaml:"num-full-nodes"`
 
 	// Generate the automatic suffix on demand when needed.
 	autoSuffixOnce sync.Once
 	autoSuffix     string
 }
 
+// clone returns a copy of the ChainSpec that does not share its ChainConfig or automatic suffix.
+func (s *ChainSpec) clone() *ChainSpec {
+	return &ChainSpec{
+		Name:          s.Name,
+		ChainName:     s.ChainName,
+		Version:       s.Version,
+		NoHostMount:   s.NoHostMount,
+		ChainConfig:   s.ChainConfig.Clone(),
+		NumValidators: s.NumValidators,
+		NumFullNodes:  s.NumFullNodes,
+	}
+}
+
 // Config returns the underlying ChainConfig,
 // with any overrides applied.
 func (s *ChainSpec) Config(log *zap.Logger) (*ibc.ChainConfig, error) {
@@ -181,28 +196,28 @@ func (s *ChainSpec) applyConfigOverrides(cfg ibc.ChainConfig) (*ibc.ChainConfig,
 				relayChainVersion = relayChainImageSplit[0]
 			}
 			cfg.Images[0].Version = relayChainVersion
-			switch {
-			case strings.Contains(s.Name, "composable"):
-				if len(versionSplit) != 2 {
-					return nil, fmt.Errorf("unexpected composable version: %s. should be comma separated polkadot:version,composable:version", s.Version)
-				}
-				imageSplit := strings.Split(versionSplit[1], ":")
-				if len(imageSplit) != 2 {
-					return nil, fmt.Errorf("parachain versions should be in the format parachain_name:parachain_version, got: %s", versionSplit[1])
-				}
-				if !strings.Contains(cfg.Images[1].Repository, imageSplit[0]) {
-					return nil, fmt.Errorf("unexpected parachain: %s", imageSplit[0])
-				}
-				cfg.Images[1].Version = imageSplit[1]
-			default:
-				return nil, fmt.Errorf("unexpected parachain: %s", s.Name)
+			if len(versionSplit) != 2 {
+				return nil, fmt.Errorf("unexpected %s version: %s. should be comma separated polkadot:version,parachain:version", s.Name, s.Version)
 			}
+			imageSplit := strings.Split(versionSplit[1], ":")
+			if len(imageSplit) != 2 {
+				return nil, fmt.Errorf("parachain versions should be in the format parachain_name:parachain_version, got: %s", versionSplit[1])
+			}
+			if len(cfg.Images) < 2 || !strings.Contains(cfg.Images[1].Repository, imageSplit[0]) {
+				return nil, fmt.Errorf("unexpected parachain: %s", imageSplit[0])
+			}
+			cfg.Images[1].Version = imageSplit[1]
 		} else {
 			// Ensure there are at least two images and check the 2nd version is populated
 			if len(s.ChainConfig.Images) < 2 || s.ChainConfig.Images[1].Version == "" {
 				return nil, fmt.Errorf("ChainCongfig.Images must be >1 and ChainConfig.Images[1].Version must not be empty")
 			}
 		}
+	default:
+		// Chain types registered with RegisterChainType take the version of their first image.
+		if s.Version != "" && len(cfg.Images) > 0 {
+			cfg.Images[0].Version = s.Version
+		}
 	}
 
 	return &cfg, nil
@@ -0,0 +1,145 @@
+package interchaintest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/penumbra"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/polkadot"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"go.uber.org/zap"
+)
+
+// ChainConstructor returns a new, uninitialized chain for cfg.
+// numValidators and numFullNodes are resolved from the ChainSpec, falling back to the defaults.
+type ChainConstructor func(log *zap.Logger, testName string, cfg ibc.ChainConfig, numValidators, numFullNodes int) (ibc.Chain, error)
+
+var (
+	chainTypesMu sync.RWMutex
+	chainTypes   = make(map[string]ChainConstructor)
+)
+
+func init() {
+	RegisterChainType("cosmos", func(log *zap.Logger, testName string, cfg ibc.ChainConfig, nv, nf int) (ibc.Chain, error) {
+		return cosmos.NewCosmosChain(testName, cfg, nv, nf, log), nil
+	})
+	RegisterChainType("penumbra", func(log *zap.Logger, testName string, cfg ibc.ChainConfig, nv, nf int) (ibc.Chain, error) {
+		return penumbra.NewPenumbraChain(log, testName, cfg, nv, nf), nil
+	})
+	RegisterChainType("polkadot", newPolkadotChain)
+}
+
+// RegisterChainType makes chains of the given type available to BuiltinChainFactory,
+// and therefore to ChainSpecs and the interchaintest test matrix, by their ChainConfig.Type.
+// It is intended to be called from the init function of the package implementing the chain.
+//
+// RegisterChainType panics if constructor is nil or if chainType is empty or already registered,
+// including the built in types "cosmos", "penumbra" and "polkadot".
+func RegisterChainType(chainType string, constructor ChainConstructor) {
+	if chainType == "" {
+		panic("interchaintest: RegisterChainType with empty chain type")
+	}
+	if constructor == nil {
+		panic("interchaintest: RegisterChainType constructor is nil for chain type " + chainType)
+	}
+
+	chainTypesMu.Lock()
+	defer chainTypesMu.Unlock()
+
+	if _, dup := chainTypes[chainType]; dup {
+		panic("interchaintest: RegisterChainType called twice for chain type " + chainType)
+	}
+	chainTypes[chainType] = constructor
+}
+
+// RegisteredChainTypes returns the sorted names of all registered chain types.
+func RegisteredChainTypes() []string {
+	chainTypesMu.RLock()
+	defer chainTypesMu.RUnlock()
+
+	types := make([]string, 0, len(chainTypes))
+	for t := range chainTypes {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func chainConstructor(chainType string) (ChainConstructor, bool) {
+	chainTypesMu.RLock()
+	defer chainTypesMu.RUnlock()
+
+	constructor, ok := chainTypes[chainType]
+	return constructor, ok
+}
+
+// ParachainsConstructor returns the parachains to run on a polkadot relay chain built for cfg.
+// numNodes is the number of nodes to run for each parachain, taken from the ChainSpec's full node count.
+type ParachainsConstructor func(cfg ibc.ChainConfig, numNodes int) []polkadot.ParachainConfig
+
+var (
+	parachainsMu sync.RWMutex
+	parachains   = make(map[string]ParachainsConstructor)
+)
+
+func init() {
+	RegisterParachains("composable", func(cfg ibc.ChainConfig, numNodes int) []polkadot.ParachainConfig {
+		return []polkadot.ParachainConfig{{
+			Bin:             "parachain-node",
+			ChainID:         "dev-2000",
+			Image:           cfg.Images[1],
+			NumNodes:        numNodes,
+			Flags:           []string{"--execution=wasm", "--wasmtime-instantiation-strategy=recreate-instance-copy-on-write"},
+			RelayChainFlags: []string{"--execution=wasm"},
+		}}
+	})
+}
+
+// RegisterParachains makes the parachains returned by constructor run on chains of type "polkadot"
+// named name, in the same way RegisterChainType makes new chain types available.
+//
+// RegisterParachains panics if constructor is nil or if name is empty or already registered,
+// including the built in "composable".
+func RegisterParachains(name string, constructor ParachainsConstructor) {
+	if name == "" {
+		panic("interchaintest: RegisterParachains with empty name")
+	}
+	if constructor == nil {
+		panic("interchaintest: RegisterParachains constructor is nil for " + name)
+	}
+
+	parachainsMu.Lock()
+	defer parachainsMu.Unlock()
+
+	if _, dup := parachains[name]; dup {
+		panic("interchaintest: RegisterParachains called twice for " + name)
+	}
+	parachains[name] = constructor
+}
+
+// parachainsConstructor returns the parachains registered for the chain name,
+// which may carry the "-N" suffix ChainSpec appends when no ChainName is set.
+func parachainsConstructor(chainName string) (ParachainsConstructor, bool) {
+	parachainsMu.RLock()
+	defer parachainsMu.RUnlock()
+
+	if constructor, ok := parachains[chainName]; ok {
+		return constructor, true
+	}
+	if i := strings.LastIndexByte(chainName, '-'); i > 0 {
+		constructor, ok := parachains[chainName[:i]]
+		return constructor, ok
+	}
+	return nil, false
+}
+
+func newPolkadotChain(log *zap.Logger, testName string, cfg ibc.ChainConfig, nv, nf int) (ibc.Chain, error) {
+	constructor, ok := parachainsConstructor(cfg.Name)
+	if !ok {
+		return nil, fmt.Errorf("unexpected error, unknown polkadot parachain: %s", cfg.Name)
+	}
+	return polkadot.NewPolkadotChain(log, testName, cfg, nv, constructor(cfg, nf)), nil
+}
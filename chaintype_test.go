@@ -0,0 +1,151 @@
+package interchaintest_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/polkadot"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+)
+
+// registeredChain is a stand-in chain recording the arguments it was constructed with.
+type registeredChain struct {
+	ibc.Chain
+
+	testName      string
+	cfg           ibc.ChainConfig
+	numValidators int
+	numFullNodes  int
+}
+
+func TestRegisterChainType(t *testing.T) {
+	// The registry is global and rejects duplicates, so use a new chain type on every run, e.g. with -count.
+	chainType := fmt.Sprintf("registered-%d", time.Now().UnixNano())
+	interchaintest.RegisterChainType(chainType, func(_ *zap.Logger, testName string, cfg ibc.ChainConfig, nv, nf int) (ibc.Chain, error) {
+		return &registeredChain{testName: testName, cfg: cfg, numValidators: nv, numFullNodes: nf}, nil
+	})
+
+	require.Contains(t, interchaintest.RegisteredChainTypes(), chainType)
+	require.Contains(t, interchaintest.RegisteredChainTypes(), "cosmos")
+
+	require.Panics(t, func() {
+		interchaintest.RegisterChainType(chainType, func(*zap.Logger, string, ibc.ChainConfig, int, int) (ibc.Chain, error) {
+			return nil, nil
+		})
+	})
+	require.Panics(t, func() {
+		interchaintest.RegisterChainType("cosmos", func(*zap.Logger, string, ibc.ChainConfig, int, int) (ibc.Chain, error) {
+			return nil, nil
+		})
+	})
+
+	numValidators := 3
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{{
+		ChainName:     "mychain",
+		Version:       "v2",
+		NumValidators: &numValidators,
+		ChainConfig: ibc.ChainConfig{
+			Type:    chainType,
+			ChainID: "mychain-1",
+			Images: []ibc.DockerImage{
+				{Repository: "docker.example.com", Version: "v1", UidGid: "1:1"},
+			},
+			Bin:            "/bin/true",
+			Bech32Prefix:   "foo",
+			Denom:          "bar",
+			GasPrices:      "1bar",
+			TrustingPeriod: "24h",
+		},
+	}})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	require.Len(t, chains, 1)
+
+	chain, ok := chains[0].(*registeredChain)
+	require.True(t, ok)
+	require.Equal(t, t.Name(), chain.testName)
+	require.Equal(t, "mychain-1", chain.cfg.ChainID)
+	require.Equal(t, "v2", chain.cfg.Images[0].Version)
+	require.Equal(t, 3, chain.numValidators)
+	require.Equal(t, 1, chain.numFullNodes)
+}
+
+func TestBuiltinChainFactory_UnknownChainType(t *testing.T) {
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{{
+		ChainName: "mychain",
+		ChainConfig: ibc.ChainConfig{
+			Type:    "unregistered",
+			ChainID: "mychain-1",
+			Images: []ibc.DockerImage{
+				{Repository: "docker.example.com", Version: "v1", UidGid: "1:1"},
+			},
+			Bin:            "/bin/true",
+			Bech32Prefix:   "foo",
+			Denom:          "bar",
+			GasPrices:      "1bar",
+			TrustingPeriod: "24h",
+		},
+	}})
+
+	_, err := cf.Chains(t.Name())
+	require.ErrorContains(t, err, "unknown chain type: unregistered")
+}
+
+func TestRegisterParachains(t *testing.T) {
+	require.Panics(t, func() {
+		interchaintest.RegisterParachains("composable", func(ibc.ChainConfig, int) []polkadot.ParachainConfig {
+			return nil
+		})
+	})
+
+	name := fmt.Sprintf("parachain-%d", time.Now().UnixNano())
+	var numNodes int
+	interchaintest.RegisterParachains(name, func(cfg ibc.ChainConfig, nf int) []polkadot.ParachainConfig {
+		numNodes = nf
+		return []polkadot.ParachainConfig{{Bin: "parachain-node", ChainID: "dev-2000", Image: cfg.Images[1], NumNodes: nf}}
+	})
+
+	numFullNodes := 2
+	images := []ibc.DockerImage{
+		{Repository: "docker.example.com/polkadot", Version: "v1", UidGid: "1:1"},
+		{Repository: "docker.example.com/parachain", Version: "v1", UidGid: "1:1"},
+	}
+	for _, tt := range []struct {
+		name    string
+		wantErr string
+	}{
+		{name, ""},
+		{"unregistered", "unknown polkadot parachain: unregistered"},
+	} {
+		cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{{
+			NumFullNodes: &numFullNodes,
+			ChainConfig: ibc.ChainConfig{
+				Type:           "polkadot",
+				Name:           tt.name,
+				ChainID:        "rococo-local",
+				Images:         images,
+				Bin:            "polkadot",
+				Bech32Prefix:   "polkadot",
+				Denom:          "uDOT",
+				GasPrices:      "0uDOT",
+				TrustingPeriod: "336h",
+			},
+		}})
+
+		chains, err := cf.Chains(t.Name())
+		if tt.wantErr != "" {
+			require.ErrorContains(t, err, tt.wantErr)
+			continue
+		}
+		require.NoError(t, err)
+		require.Len(t, chains, 1)
+		require.IsType(t, &polkadot.PolkadotChain{}, chains[0])
+		require.Equal(t, numFullNodes, numNodes)
+	}
+}
@@ -0,0 +1,115 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestCosmosHubGRPCQuery(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	numVals := 1
+	numFullNodes := 1
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:          "gaia",
+			ChainName:     "gaia",
+			Version:       gaiaVersion,
+			NumValidators: &numVals,
+			NumFullNodes:  &numFullNodes,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:         t.Name(),
+		Client:           client,
+		NetworkID:        network,
+		SkipPathCreation: true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", int64(10_000_000_000), chain)
+	user := users[0]
+	denom := chain.Config().Denom
+
+	balanceReq := &banktypes.QueryBalanceRequest{Address: user.FormattedAddress(), Denom: denom}
+
+	t.Run("default node", func(t *testing.T) {
+		res, err := cosmos.GRPCQuery(ctx, chain, banktypes.NewQueryClient, banktypes.QueryClient.Balance, balanceReq, cosmos.GRPCQueryOptions{})
+		require.NoError(t, err)
+		require.Equal(t, sdkmath.NewInt(10_000_000_000), res.Balance.Amount)
+	})
+
+	t.Run("validator node", func(t *testing.T) {
+		res, err := cosmos.GRPCQuery(ctx, chain, banktypes.NewQueryClient, banktypes.QueryClient.Balance, balanceReq, cosmos.GRPCQueryOptions{
+			Node: chain.Validators[0],
+		})
+		require.NoError(t, err)
+		require.Equal(t, sdkmath.NewInt(10_000_000_000), res.Balance.Amount)
+	})
+
+	t.Run("height", func(t *testing.T) {
+		recipient := interchaintest.GetAndFundTestUsers(t, ctx, "recipient", int64(1), chain)[0]
+
+		height, err := chain.Height(ctx)
+		require.NoError(t, err)
+
+		require.NoError(t, chain.SendFunds(ctx, user.KeyName(), ibc.WalletAmount{
+			Address: recipient.FormattedAddress(),
+			Denom:   denom,
+			Amount:  sdkmath.NewInt(1),
+		}))
+
+		res, err := cosmos.GRPCQuery(ctx, chain, banktypes.NewQueryClient, banktypes.QueryClient.Balance, balanceReq, cosmos.GRPCQueryOptions{
+			Height: int64(height),
+		})
+		require.NoError(t, err)
+		require.Equal(t, sdkmath.NewInt(10_000_000_000), res.Balance.Amount)
+
+		res, err = cosmos.GRPCQuery(ctx, chain, banktypes.NewQueryClient, banktypes.QueryClient.Balance, balanceReq, cosmos.GRPCQueryOptions{})
+		require.NoError(t, err)
+		require.True(t, res.Balance.Amount.LT(sdkmath.NewInt(10_000_000_000)))
+	})
+
+	t.Run("interfaces", func(t *testing.T) {
+		conn, err := chain.GRPCConn()
+		require.NoError(t, err)
+		defer conn.Close()
+
+		res, err := stakingtypes.NewQueryClient(conn).Validators(ctx, &stakingtypes.QueryValidatorsRequest{})
+		require.NoError(t, err)
+		require.Len(t, res.Validators, 1)
+
+		// The consensus public key is unpacked with the chain's interface registry.
+		pubKey, err := res.Validators[0].ConsPubKey()
+		require.NoError(t, err)
+		require.NotNil(t, pubKey)
+	})
+}
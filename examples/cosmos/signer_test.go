@@ -0,0 +1,136 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestCosmosHubSigner(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	numVals := 1
+	numFullNodes := 0
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:          "gaia",
+			ChainName:     "gaia",
+			Version:       gaiaVersion,
+			NumValidators: &numVals,
+			NumFullNodes:  &numFullNodes,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:         t.Name(),
+		Client:           client,
+		NetworkID:        network,
+		SkipPathCreation: true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	denom := chain.Config().Denom
+
+	// The key of the wallet only exists on the host, so its transactions cannot be signed in a node container.
+	wallet, err := chain.BuildRelayerWallet(ctx, "signer")
+	require.NoError(t, err)
+	require.NoError(t, chain.SendFunds(ctx, interchaintest.FaucetAccountKeyName, ibc.WalletAmount{
+		Address: wallet.FormattedAddress(),
+		Denom:   denom,
+		Amount:  sdkmath.NewInt(10_000_000_000),
+	}))
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, chain))
+
+	recipient := interchaintest.GetAndFundTestUsers(t, ctx, "recipient", int64(1), chain)[0]
+
+	signer, err := cosmos.NewSigner(chain, wallet)
+	require.NoError(t, err)
+
+	send := func(amount int64) sdk.Msg {
+		return &banktypes.MsgSend{
+			FromAddress: wallet.FormattedAddress(),
+			ToAddress:   recipient.FormattedAddress(),
+			Amount:      sdk.NewCoins(sdk.NewInt64Coin(denom, amount)),
+		}
+	}
+
+	t.Run("commit", func(t *testing.T) {
+		res, err := signer.Broadcast(ctx, cosmos.TxOptions{Memo: "interchaintest"}, send(100))
+		require.NoError(t, err)
+		require.Zero(t, res.Code)
+		require.NotZero(t, res.Height)
+		require.NotEmpty(t, res.Events)
+		require.NotNil(t, res.Tx)
+
+		balance, err := chain.GetBalance(ctx, recipient.FormattedAddress(), denom)
+		require.NoError(t, err)
+		require.Equal(t, sdkmath.NewInt(101), balance)
+	})
+
+	t.Run("sync", func(t *testing.T) {
+		// Both transactions are signed with consecutive sequences without waiting for a block.
+		first, err := signer.Broadcast(ctx, cosmos.TxOptions{Mode: cosmos.BroadcastModeSync}, send(10))
+		require.NoError(t, err)
+		require.NotEmpty(t, first.TxHash)
+
+		second, err := signer.Broadcast(ctx, cosmos.TxOptions{Mode: cosmos.BroadcastModeSync}, send(10))
+		require.NoError(t, err)
+		require.NotEqual(t, first.TxHash, second.TxHash)
+
+		require.NoError(t, testutil.WaitForBlocks(ctx, 2, chain))
+
+		balance, err := chain.GetBalance(ctx, recipient.FormattedAddress(), denom)
+		require.NoError(t, err)
+		require.Equal(t, sdkmath.NewInt(121), balance)
+	})
+
+	t.Run("multiple messages", func(t *testing.T) {
+		res, err := signer.Broadcast(ctx, cosmos.TxOptions{
+			Gas:  500_000,
+			Fees: sdk.NewCoins(sdk.NewInt64Coin(denom, 50_000)),
+		}, send(1), send(2))
+		require.NoError(t, err)
+		require.Equal(t, int64(500_000), res.GasWanted)
+	})
+
+	t.Run("failed transaction", func(t *testing.T) {
+		_, err := signer.Broadcast(ctx, cosmos.TxOptions{Gas: 500_000}, send(1_000_000_000_000))
+		require.Error(t, err)
+
+		// The signer recovers its sequence after a failure.
+		_, err = signer.Broadcast(ctx, cosmos.TxOptions{}, send(1))
+		require.NoError(t, err)
+	})
+
+	t.Run("no mnemonic", func(t *testing.T) {
+		_, err := cosmos.NewSigner(chain, recipient)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,121 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestCosmosHubSlashing(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	// One validator can be down while the others keep producing blocks.
+	numVals := 4
+	numFullNodes := 0
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:      "gaia",
+			ChainName: "gaia",
+			Version:   gaiaVersion,
+			ChainConfig: ibc.ChainConfig{
+				ModifyGenesis: cosmos.ModifyGenesis([]cosmos.GenesisKV{
+					{Key: "app_state.slashing.params.signed_blocks_window", Value: "10"},
+					{Key: "app_state.slashing.params.min_signed_per_window", Value: "0.500000000000000000"},
+					{Key: "app_state.slashing.params.downtime_jail_duration", Value: "10s"},
+				}),
+			},
+			NumValidators: &numVals,
+			NumFullNodes:  &numFullNodes,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:         t.Name(),
+		Client:           client,
+		NetworkID:        network,
+		SkipPathCreation: true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	t.Run("downtime", func(t *testing.T) {
+		val := chain.Validators[1]
+
+		valoper, err := val.ValidatorOperatorAddress(ctx)
+		require.NoError(t, err)
+		consAddr, err := val.ValidatorConsensusAddress(ctx)
+		require.NoError(t, err)
+
+		jailed, err := chain.IsJailed(ctx, valoper)
+		require.NoError(t, err)
+		require.False(t, jailed)
+
+		require.NoError(t, chain.ForceDowntime(ctx, val, 15))
+
+		jailed, err = chain.IsJailed(ctx, valoper)
+		require.NoError(t, err)
+		require.True(t, jailed)
+
+		info, err := chain.QuerySigningInfo(ctx, consAddr)
+		require.NoError(t, err)
+		require.False(t, info.Tombstoned)
+		require.True(t, info.JailedUntil.After(time.Time{}))
+
+		slashes, err := chain.QuerySlashes(ctx, valoper)
+		require.NoError(t, err)
+		require.Len(t, slashes, 1)
+
+		// Wait for the downtime jail duration to elapse.
+		time.Sleep(time.Until(info.JailedUntil))
+		require.NoError(t, chain.Unjail(ctx, val))
+
+		jailed, err = chain.IsJailed(ctx, valoper)
+		require.NoError(t, err)
+		require.False(t, jailed)
+	})
+
+	t.Run("double sign", func(t *testing.T) {
+		val := chain.Validators[2]
+
+		valoper, err := val.ValidatorOperatorAddress(ctx)
+		require.NoError(t, err)
+
+		require.NoError(t, chain.DoubleSign(ctx, val, 30))
+		require.Empty(t, chain.FullNodes)
+
+		jailed, err := chain.IsJailed(ctx, valoper)
+		require.NoError(t, err)
+		require.True(t, jailed)
+
+		slashes, err := chain.QuerySlashes(ctx, valoper)
+		require.NoError(t, err)
+		require.NotEmpty(t, slashes)
+
+		// The chain keeps producing blocks without the tombstoned validator.
+		require.NoError(t, testutil.WaitForBlocks(ctx, 2, chain))
+	})
+}
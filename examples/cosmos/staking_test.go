@@ -0,0 +1,133 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestCosmosHubStaking(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	numVals := 2
+	numFullNodes := 0
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:          "gaia",
+			ChainName:     "gaia",
+			Version:       gaiaVersion,
+			NumValidators: &numVals,
+			NumFullNodes:  &numFullNodes,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:         t.Name(),
+		Client:           client,
+		NetworkID:        network,
+		SkipPathCreation: true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", int64(10_000_000_000), chain)
+	user := users[0]
+	delegator := user.FormattedAddress()
+
+	validators, err := chain.QueryValidators(ctx, stakingtypes.BondStatusBonded)
+	require.NoError(t, err)
+	require.Len(t, validators, 2)
+
+	val0, val1 := validators[0].OperatorAddress, validators[1].OperatorAddress
+
+	// The consensus public key is unpacked with the chain's encoding config.
+	pubKey, err := validators[0].ConsPubKey()
+	require.NoError(t, err)
+	require.NotNil(t, pubKey)
+
+	amount := sdk.NewCoin(chain.Config().Denom, sdkmath.NewInt(1_000_000_000))
+
+	t.Run("delegate", func(t *testing.T) {
+		require.NoError(t, chain.Delegate(ctx, user.KeyName(), val0, amount))
+
+		delegation, err := chain.QueryDelegation(ctx, delegator, val0)
+		require.NoError(t, err)
+		require.Equal(t, amount, delegation.Balance)
+	})
+
+	t.Run("redelegate", func(t *testing.T) {
+		half := sdk.NewCoin(amount.Denom, amount.Amount.QuoRaw(2))
+		require.NoError(t, chain.Redelegate(ctx, user.KeyName(), val0, val1, half))
+
+		delegations, err := chain.QueryDelegations(ctx, delegator)
+		require.NoError(t, err)
+		require.Len(t, delegations, 2)
+		for _, d := range delegations {
+			require.Equal(t, half, d.Balance)
+		}
+	})
+
+	t.Run("rewards", func(t *testing.T) {
+		require.NoError(t, testutil.WaitForBlocks(ctx, 2, chain))
+
+		outstanding, err := chain.QueryOutstandingRewards(ctx, val0)
+		require.NoError(t, err)
+		require.False(t, outstanding.IsZero())
+
+		rewards, err := chain.QueryDelegationRewards(ctx, delegator, val0)
+		require.NoError(t, err)
+		require.False(t, rewards.IsZero())
+
+		require.NoError(t, chain.WithdrawRewards(ctx, user.KeyName(), val0))
+	})
+
+	t.Run("commission", func(t *testing.T) {
+		val := chain.Validators[0]
+		valoper, err := val.ValidatorOperatorAddress(ctx)
+		require.NoError(t, err)
+
+		commission, err := chain.QueryCommission(ctx, valoper)
+		require.NoError(t, err)
+		require.False(t, commission.IsZero())
+
+		require.NoError(t, chain.WithdrawCommission(ctx, val))
+	})
+
+	t.Run("undelegate", func(t *testing.T) {
+		half := sdk.NewCoin(amount.Denom, amount.Amount.QuoRaw(2))
+		require.NoError(t, chain.Undelegate(ctx, user.KeyName(), val1, half))
+
+		unbonding, err := chain.QueryUnbondingDelegation(ctx, delegator, val1)
+		require.NoError(t, err)
+		require.Len(t, unbonding.Entries, 1)
+		require.Equal(t, half.Amount, unbonding.Entries[0].Balance)
+
+		_, err = chain.QueryDelegation(ctx, delegator, val1)
+		require.Error(t, err)
+	})
+}
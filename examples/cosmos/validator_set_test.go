@@ -0,0 +1,75 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestCosmosHubValidatorSet(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	numVals := 2
+	numFullNodes := 0
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:          "gaia",
+			ChainName:     "gaia",
+			Version:       gaiaVersion,
+			NumValidators: &numVals,
+			NumFullNodes:  &numFullNodes,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:         t.Name(),
+		Client:           client,
+		NetworkID:        network,
+		SkipPathCreation: true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	selfDelegation := sdk.NewCoin(chain.Config().Denom, sdkmath.NewInt(1_000_000_000_000))
+	require.NoError(t, chain.AddValidators(ctx, nil, 1, selfDelegation))
+	require.Len(t, chain.Validators, 3)
+
+	newVal := chain.Validators[2]
+	require.True(t, newVal.Validator)
+
+	require.NoError(t, chain.RemoveValidator(ctx, newVal))
+	require.Len(t, chain.Validators, 2)
+	require.NotContains(t, chain.Validators, newVal)
+
+	// The chain keeps producing blocks with the remaining validators.
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, chain))
+
+	// A validator added after a removal gets a new node name.
+	require.NoError(t, chain.AddValidators(ctx, nil, 1, selfDelegation))
+	require.Len(t, chain.Validators, 3)
+	require.NotEqual(t, newVal.Name(), chain.Validators[2].Name())
+}
@@ -269,7 +269,7 @@ func (o Order) Validate() error {
 
 // CreateClientOptions contains the configuration for creating a client.
 type CreateClientOptions struct {
-	TrustingPeriod string
+	TrustingPeriod string `yaml:"trusting-period"`
 }
 
 // DefaultClientOpts returns the default settings for creating clients.
@@ -13,6 +13,7 @@ type Tx struct {
 	// The transaction hash.
 	TxHash string
 	// Amount of gas charged to the account.
+	// Chains without gas, such as Penumbra, report the fee paid in their native denom instead.
 	GasSpent int64
 
 	Packet Packet
@@ -188,6 +188,14 @@ func (c ChainConfig) IsFullyConfigured() bool {
 		c.TrustingPeriod != ""
 }
 
+// NodeContainers names the docker containers running a node of a chain.
+// Chains list them through a NodeContainers() []NodeContainers method, used by the Interchain manifest.
+type NodeContainers struct {
+	Validator bool
+	// Names of the containers, starting with the container of the node's main process.
+	Containers []string
+}
+
 // SidecarConfig describes the configuration options for instantiating a new sidecar process.
 type SidecarConfig struct {
 	ProcessName      string
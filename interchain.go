@@ -3,11 +3,13 @@ package interchaintest
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"cosmossdk.io/math"
 	"github.com/docker/docker/client"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
 	"github.com/strangelove-ventures/interchaintest/v8/testreporter"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
@@ -36,8 +38,21 @@ type Interchain struct {
 	// Map of chain to additional genesis wallets to include at chain start.
 	AdditionalGenesisWallets map[ibc.Chain][]ibc.WalletAmount
 
+	// Map of chain to every wallet funded in its genesis, set when the chain is started.
+	genesisWallets map[ibc.Chain][]ibc.WalletAmount
+
 	// Set during Build and cleaned up in the Close method.
 	cs *chainSet
+
+	// Docker client and test name of the Build, used to snapshot the volumes of the Interchain.
+	cli      *client.Client
+	testName string
+
+	// Chain sets started by Extend, cleaned up in the Close method alongside cs.
+	extensions []*chainSet
+
+	// Links whose paths were handled by Build or Extend.
+	builtLinks map[relayerPath]struct{}
 }
 
 type interchainLink struct {
@@ -57,6 +72,7 @@ type interchainLink struct {
 //
 // Typical usage involves multiple calls to AddChain, one or more calls to AddRelayer,
 // one or more calls to AddLink, and then finally a single call to Build.
+// Chains, relayers and links added after Build are built by calling Extend.
 func NewInterchain() *Interchain {
 	return &Interchain{
 		log: zap.NewNop(),
@@ -209,16 +225,60 @@ type InterchainBuildOptions struct {
 
 	// If set, saves block history to a sqlite3 database to aid debugging.
 	BlockDatabaseFile string
+
+	// If set, Build restores the Interchain from the snapshot of the same topology in this directory, if there is one,
+	// instead of building it from scratch. Otherwise, Build snapshots the Interchain into the directory once it is built.
+	// Restoring would skip the BeforeChainsStart, BeforeLink and AfterLink hooks, so Build returns an error if they are set,
+	// as it does for chains and relayers without snapshot support, see Interchain.Snapshot. Snapshots are keyed by chain
+	// names and IDs, so set them explicitly in ChainSpecs to share snapshots across tests.
+	SnapshotDir string
+
+	// Optional hooks called at the corresponding stage of Build.
+	// If a hook returns an error, Build stops and returns the error.
+
+	// AfterInitialize is called once the chains have been initialized, before any wallets are generated.
+	AfterInitialize BuildHookFunc
+	// BeforeChainsStart is the genesis hook, called once the genesis wallets of every chain have been generated.
+	// It runs before, not after, the genesis files are created: each chain creates and modifies its genesis file
+	// while it starts, so the hook gets the genesis wallets, which it may add, remove or change, instead of the files.
+	// To inspect or change the genesis file of a chain, use the ModifyGenesis option of its chain config.
+	BeforeChainsStart GenesisHookFunc
+	// AfterChainsStart is called once every chain is producing blocks, before the relayers are configured.
+	AfterChainsStart BuildHookFunc
+	// BeforeLink and AfterLink are called before and after the clients, connections and channel of each link
+	// are created. They are not called if SkipPathCreation is set.
+	// Links are created concurrently, so these hooks must be safe for concurrent use.
+	BeforeLink, AfterLink LinkHookFunc
+	// AfterBuild is called once everything else has been built, as the last step of Build.
+	AfterBuild BuildHookFunc
 }
 
+// BuildHookFunc is a hook called by Interchain.Build with all chains and relayers of the Interchain,
+// each ordered by name.
+type BuildHookFunc func(ctx context.Context, chains []ibc.Chain, relayers []ibc.Relayer) error
+
+// GenesisHookFunc is a hook called by Interchain.Build with all chains and relayers of the Interchain,
+// each ordered by name as for BuildHookFunc, and the wallets to fund in the genesis of each chain.
+type GenesisHookFunc func(ctx context.Context, chains []ibc.Chain, relayers []ibc.Relayer, walletAmounts map[ibc.Chain][]ibc.WalletAmount) error
+
+// LinkHookFunc is a hook called by Interchain.Build with a link of the Interchain.
+// The link holds the client and channel options used to create the link.
+type LinkHookFunc func(ctx context.Context, link InterchainLink) error
+
 // Build starts all the chains and configures the relayers associated with the Interchain.
 // It is the caller's responsibility to directly call StartRelayer on the relayer implementations.
 //
-// Calling Build more than once will cause a panic.
+// Calling Build more than once will cause a panic. Use Extend to build chains and links added later.
 func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecReporter, opts InterchainBuildOptions) error {
 	if ic.built {
 		panic(fmt.Errorf("Interchain.Build called more than once"))
 	}
+
+	if opts.SnapshotDir != "" {
+		if err := ic.validateSnapshotOptions(opts); err != nil {
+			return fmt.Errorf("invalid snapshot options: %w", err)
+		}
+	}
 	ic.built = true
 
 	chains := make([]ibc.Chain, 0, len(ic.chains))
@@ -226,23 +286,51 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 		chains = append(chains, chain)
 	}
 	ic.cs = newChainSet(ic.log, chains)
+	ic.cli, ic.testName = opts.Client, opts.TestName
 
 	// Initialize the chains (pull docker images, etc.).
 	if err := ic.cs.Initialize(ctx, opts.TestName, opts.Client, opts.NetworkID); err != nil {
 		return fmt.Errorf("failed to initialize chains: %w", err)
 	}
 
-	err := ic.generateRelayerWallets(ctx) // Build the relayer wallet mapping.
+	if err := ic.runBuildHook(ctx, "AfterInitialize", opts.AfterInitialize); err != nil {
+		return err
+	}
+
+	if opts.SnapshotDir != "" {
+		restored, err := ic.restoreSnapshot(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("failed to restore snapshot: %w", err)
+		}
+		if restored {
+			if err := ic.cs.TrackBlocks(ctx, opts.TestName, opts.BlockDatabaseFile, opts.GitSha); err != nil {
+				return fmt.Errorf("failed to track blocks: %w", err)
+			}
+			if err := ic.runBuildHook(ctx, "AfterChainsStart", opts.AfterChainsStart); err != nil {
+				return err
+			}
+			return ic.runBuildHook(ctx, "AfterBuild", opts.AfterBuild)
+		}
+	}
+
+	relayerWallets, err := ic.generateRelayerWallets(ctx) // Build the relayer wallet mapping.
 	if err != nil {
 		return err
 	}
 
-	walletAmounts, err := ic.genesisWalletAmounts(ctx)
+	walletAmounts, err := ic.genesisWalletAmounts(ctx, ic.cs, relayerWallets)
 	if err != nil {
 		// Error already wrapped with appropriate detail.
 		return err
 	}
 
+	if opts.BeforeChainsStart != nil {
+		if err := opts.BeforeChainsStart(ctx, ic.sortedChains(), ic.sortedRelayers(), walletAmounts); err != nil {
+			return fmt.Errorf("BeforeChainsStart hook failed: %w", err)
+		}
+	}
+	ic.recordGenesisWallets(walletAmounts)
+
 	if err := ic.cs.Start(ctx, opts.TestName, walletAmounts); err != nil {
 		return fmt.Errorf("failed to start chains: %w", err)
 	}
@@ -260,11 +348,177 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 		}
 	}
 
-	if err := ic.configureRelayerKeys(ctx, rep); err != nil {
+	if err := ic.runBuildHook(ctx, "AfterChainsStart", opts.AfterChainsStart); err != nil {
+		return err
+	}
+
+	if err := ic.configureRelayerKeys(ctx, rep, relayerWallets); err != nil {
+		// Error already wrapped with appropriate detail.
+		return err
+	}
+
+	if err := ic.linkPaths(ctx, rep, opts); err != nil {
+		return err
+	}
+
+	if opts.SnapshotDir != "" {
+		if err := ic.Snapshot(ctx, opts.SnapshotDir); err != nil {
+			return fmt.Errorf("failed to snapshot Interchain: %w", err)
+		}
+	}
+
+	return ic.runBuildHook(ctx, "AfterBuild", opts.AfterBuild)
+}
+
+// Extend builds the chains, relayers and links added to the Interchain since it was built,
+// while the chains and relayers built earlier keep running.
+//
+// New chains are started with a faucet, like the chains started by Build.
+// Relayer wallets for new relayer-chain pairs are funded in genesis on new chains,
+// and from the faucet on chains that were already running.
+// Unless SkipPathCreation is set, paths are then created for the new links only.
+// A relayer that is already running must be restarted to relay the new paths.
+//
+// The build hooks of opts are called as in Build, with all chains and relayers of the Interchain.
+// BeforeChainsStart is called with all chains and relayers, but only with the genesis wallets of the new chains.
+//
+// Calling Extend before Build will cause a panic.
+func (ic *Interchain) Extend(ctx context.Context, rep *testreporter.RelayerExecReporter, opts InterchainBuildOptions) error {
+	if !ic.built {
+		panic(fmt.Errorf("Interchain.Extend called before Build"))
+	}
+
+	var chains []ibc.Chain
+	for c := range ic.chains {
+		if !ic.chainBuilt(c) {
+			chains = append(chains, c)
+		}
+	}
+	cs := newChainSet(ic.log, chains)
+
+	if err := cs.Initialize(ctx, opts.TestName, opts.Client, opts.NetworkID); err != nil {
+		return fmt.Errorf("failed to initialize chains: %w", err)
+	}
+	ic.extensions = append(ic.extensions, cs)
+
+	if err := ic.runBuildHook(ctx, "AfterInitialize", opts.AfterInitialize); err != nil {
+		return err
+	}
+
+	relayerWallets, err := ic.generateRelayerWallets(ctx)
+	if err != nil {
+		return err
+	}
+
+	walletAmounts, err := ic.genesisWalletAmounts(ctx, cs, relayerWallets)
+	if err != nil {
+		// Error already wrapped with appropriate detail.
+		return err
+	}
+
+	if opts.BeforeChainsStart != nil {
+		if err := opts.BeforeChainsStart(ctx, ic.sortedChains(), ic.sortedRelayers(), walletAmounts); err != nil {
+			return fmt.Errorf("BeforeChainsStart hook failed: %w", err)
+		}
+	}
+	ic.recordGenesisWallets(walletAmounts)
+
+	if err := cs.Start(ctx, opts.TestName, walletAmounts); err != nil {
+		return fmt.Errorf("failed to start chains: %w", err)
+	}
+
+	if err := cs.TrackBlocks(ctx, opts.TestName, opts.BlockDatabaseFile, opts.GitSha); err != nil {
+		return fmt.Errorf("failed to track blocks: %w", err)
+	}
+
+	for c := range cs.chains {
+		if err := CreatePenumbraClient(ctx, c, FaucetAccountKeyName); err != nil {
+			return err
+		}
+	}
+
+	if err := ic.fundRelayerWallets(ctx, cs, relayerWallets); err != nil {
+		return err
+	}
+
+	if err := ic.runBuildHook(ctx, "AfterChainsStart", opts.AfterChainsStart); err != nil {
+		return err
+	}
+
+	if err := ic.configureRelayerKeys(ctx, rep, relayerWallets); err != nil {
 		// Error already wrapped with appropriate detail.
 		return err
 	}
 
+	if err := ic.linkPaths(ctx, rep, opts); err != nil {
+		return err
+	}
+
+	return ic.runBuildHook(ctx, "AfterBuild", opts.AfterBuild)
+}
+
+// recordGenesisWallets records the genesis wallets of started chains, to be reported by Manifest.
+func (ic *Interchain) recordGenesisWallets(walletAmounts map[ibc.Chain][]ibc.WalletAmount) {
+	if ic.genesisWallets == nil {
+		ic.genesisWallets = make(map[ibc.Chain][]ibc.WalletAmount, len(walletAmounts))
+	}
+	for c, wallets := range walletAmounts {
+		ic.genesisWallets[c] = wallets
+	}
+}
+
+// chainBuilt reports whether the chain was started by Build or Extend.
+func (ic *Interchain) chainBuilt(c ibc.Chain) bool {
+	if _, ok := ic.cs.chains[c]; ok {
+		return true
+	}
+	for _, cs := range ic.extensions {
+		if _, ok := cs.chains[c]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// fundRelayerWallets sends funds from the faucet to the relayer wallets of chains outside of cs,
+// which were already running when the wallets were generated.
+func (ic *Interchain) fundRelayerWallets(ctx context.Context, cs *chainSet, relayerWallets map[relayerChain]ibc.Wallet) error {
+	for rc, wallet := range relayerWallets {
+		c := rc.C
+		if _, ok := cs.chains[c]; ok {
+			// Funded in genesis.
+			continue
+		}
+
+		if err := c.SendFunds(ctx, FaucetAccountKeyName, ibc.WalletAmount{
+			Address: wallet.FormattedAddress(),
+			Denom:   c.Config().Denom,
+			Amount:  math.NewInt(1_000_000_000_000), // Every wallet gets 1t units of denom.
+		}); err != nil {
+			return fmt.Errorf("failed to fund wallet of relayer %s on chain %s: %w", ic.relayers[rc.R], ic.chains[c], err)
+		}
+	}
+
+	return nil
+}
+
+// linkPaths generates and links the paths of the links that were not handled by a previous call,
+// unless SkipPathCreation is set.
+func (ic *Interchain) linkPaths(ctx context.Context, rep *testreporter.RelayerExecReporter, opts InterchainBuildOptions) error {
+	links := make(map[relayerPath]interchainLink, len(ic.links))
+	for rp, link := range ic.links {
+		if _, ok := ic.builtLinks[rp]; !ok {
+			links[rp] = link
+		}
+	}
+
+	if ic.builtLinks == nil {
+		ic.builtLinks = make(map[relayerPath]struct{}, len(links))
+	}
+	for rp := range links {
+		ic.builtLinks[rp] = struct{}{}
+	}
+
 	// Some tests may want to configure the relayer from a lower level,
 	// but still have wallets configured.
 	if opts.SkipPathCreation {
@@ -272,7 +526,7 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 	}
 
 	// For every relayer link, teach the relayer about the link and create the link.
-	for rp, link := range ic.links {
+	for rp, link := range links {
 		rp := rp
 		link := link
 		c0 := link.chains[0]
@@ -289,7 +543,7 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 	// Now link the paths in parallel
 	// Creates clients, connections, and channels for each link/path.
 	var eg errgroup.Group
-	for rp, link := range ic.links {
+	for rp, link := range links {
 		rp := rp
 		link := link
 		c0 := link.chains[0]
@@ -317,12 +571,33 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 				return err
 			}
 
+			hookLink := InterchainLink{
+				Chain1:            c0,
+				Chain2:            c1,
+				Relayer:           rp.Relayer,
+				Path:              rp.Path,
+				CreateClientOpts:  link.createClientOpts,
+				CreateChannelOpts: link.createChannelOpts,
+			}
+
+			if opts.BeforeLink != nil {
+				if err := opts.BeforeLink(ctx, hookLink); err != nil {
+					return fmt.Errorf("BeforeLink hook failed for path %s: %w", rp.Path, err)
+				}
+			}
+
 			if err := rp.Relayer.LinkPath(ctx, rep, rp.Path, link.createChannelOpts, link.createClientOpts); err != nil {
 				return fmt.Errorf(
 					"failed to link path %s on relayer %s between chains %s and %s: %w",
 					rp.Path, rp.Relayer, ic.chains[c0], ic.chains[c1], err,
 				)
 			}
+
+			if opts.AfterLink != nil {
+				if err := opts.AfterLink(ctx, hookLink); err != nil {
+					return fmt.Errorf("AfterLink hook failed for path %s: %w", rp.Path, err)
+				}
+			}
 			return nil
 		})
 	}
@@ -330,6 +605,61 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 	return eg.Wait()
 }
 
+// runBuildHook calls the build hook with the given name, if set.
+func (ic *Interchain) runBuildHook(ctx context.Context, name string, hook BuildHookFunc) error {
+	if hook == nil {
+		return nil
+	}
+	if err := hook(ctx, ic.sortedChains(), ic.sortedRelayers()); err != nil {
+		return fmt.Errorf("%s hook failed: %w", name, err)
+	}
+	return nil
+}
+
+// sortedChains returns the chains of the Interchain, ordered by chain name.
+func (ic *Interchain) sortedChains() []ibc.Chain {
+	chains := make([]ibc.Chain, 0, len(ic.chains))
+	for c := range ic.chains {
+		chains = append(chains, c)
+	}
+	sort.Slice(chains, func(i, j int) bool {
+		return chains[i].Config().Name < chains[j].Config().Name
+	})
+	return chains
+}
+
+// sortedRelayers returns the relayers of the Interchain, ordered by relayer name.
+func (ic *Interchain) sortedRelayers() []ibc.Relayer {
+	relayers := make([]ibc.Relayer, 0, len(ic.relayers))
+	for r := range ic.relayers {
+		relayers = append(relayers, r)
+	}
+	sort.Slice(relayers, func(i, j int) bool {
+		return ic.relayers[relayers[i]] < ic.relayers[relayers[j]]
+	})
+	return relayers
+}
+
+// Chain returns the chain with the given name, i.e. the Name of its chain config.
+func (ic *Interchain) Chain(name string) (ibc.Chain, bool) {
+	for c := range ic.chains {
+		if c.Config().Name == name {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// Relayer returns the relayer added with the given name.
+func (ic *Interchain) Relayer(name string) (ibc.Relayer, bool) {
+	for r, n := range ic.relayers {
+		if n == name {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
 // WithLog sets the logger on the interchain object.
 // Usually the default nop logger is fine, but sometimes it can be helpful
 // to see more verbose logs, typically by passing zaptest.NewLogger(t).
@@ -341,21 +671,27 @@ func (ic *Interchain) WithLog(log *zap.Logger) *Interchain {
 // Close cleans up any resources created during Build,
 // and returns any relevant errors.
 func (ic *Interchain) Close() error {
-	return ic.cs.Close()
+	err := ic.cs.Close()
+	for _, cs := range ic.extensions {
+		multierr.AppendInto(&err, cs.Close())
+	}
+	return err
 }
 
-func (ic *Interchain) genesisWalletAmounts(ctx context.Context) (map[ibc.Chain][]ibc.WalletAmount, error) {
+// genesisWalletAmounts returns the genesis wallets of the chains in cs,
+// including the wallets of the given relayer-chain pairs on those chains.
+func (ic *Interchain) genesisWalletAmounts(ctx context.Context, cs *chainSet, relayerWallets map[relayerChain]ibc.Wallet) (map[ibc.Chain][]ibc.WalletAmount, error) {
 	// Faucet addresses are created separately because they need to be explicitly added to the chains.
-	faucetAddresses, err := ic.cs.CreateCommonAccount(ctx, FaucetAccountKeyName)
+	faucetAddresses, err := cs.CreateCommonAccount(ctx, FaucetAccountKeyName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create faucet accounts: %w", err)
 	}
 
 	// Wallet amounts for genesis.
-	walletAmounts := make(map[ibc.Chain][]ibc.WalletAmount, len(ic.cs.chains))
+	walletAmounts := make(map[ibc.Chain][]ibc.WalletAmount, len(cs.chains))
 
 	// Add faucet for each chain first.
-	for c := range ic.chains {
+	for c := range cs.chains {
 		// The values are nil at this point, so it is safe to directly assign the slice.
 		walletAmounts[c] = []ibc.WalletAmount{
 			{
@@ -371,8 +707,11 @@ func (ic *Interchain) genesisWalletAmounts(ctx context.Context) (map[ibc.Chain][
 	}
 
 	// Then add all defined relayer wallets.
-	for rc, wallet := range ic.relayerWallets {
+	for rc, wallet := range relayerWallets {
 		c := rc.C
+		if _, ok := cs.chains[c]; !ok {
+			continue
+		}
 		walletAmounts[c] = append(walletAmounts[c], ibc.WalletAmount{
 			Address: wallet.FormattedAddress(),
 			Denom:   c.Config().Denom,
@@ -383,58 +722,65 @@ func (ic *Interchain) genesisWalletAmounts(ctx context.Context) (map[ibc.Chain][
 	return walletAmounts, nil
 }
 
-// generateRelayerWallets populates ic.relayerWallets.
-func (ic *Interchain) generateRelayerWallets(ctx context.Context) error {
-	if ic.relayerWallets != nil {
-		panic(fmt.Errorf("cannot call generateRelayerWallets more than once"))
+// generateRelayerWallets adds a wallet to ic.relayerWallets for each relayer-chain pair that does not have one yet,
+// and returns the new wallets.
+func (ic *Interchain) generateRelayerWallets(ctx context.Context) (map[relayerChain]ibc.Wallet, error) {
+	relayerChains := ic.relayerChains()
+	if ic.relayerWallets == nil {
+		ic.relayerWallets = make(map[relayerChain]ibc.Wallet, len(relayerChains))
 	}
 
-	relayerChains := ic.relayerChains()
-	ic.relayerWallets = make(map[relayerChain]ibc.Wallet, len(relayerChains))
+	newWallets := make(map[relayerChain]ibc.Wallet)
 	for r, chains := range relayerChains {
 		for _, c := range chains {
+			rc := relayerChain{R: r, C: c}
+			if _, exists := ic.relayerWallets[rc]; exists {
+				continue
+			}
+
 			// Just an ephemeral unique name, only for the local use of the keyring.
 			accountName := ic.relayers[r] + "-" + ic.chains[c]
 			newWallet, err := c.BuildRelayerWallet(ctx, accountName)
 			if err != nil {
-				return err
+				return nil, err
 			}
-			ic.relayerWallets[relayerChain{R: r, C: c}] = newWallet
+			ic.relayerWallets[rc] = newWallet
+			newWallets[rc] = newWallet
 		}
 	}
 
-	return nil
+	return newWallets, nil
 }
 
-// configureRelayerKeys adds the chain configuration for each relayer
-// and adds the preconfigured key to the relayer for each relayer-chain.
-func (ic *Interchain) configureRelayerKeys(ctx context.Context, rep *testreporter.RelayerExecReporter) error {
+// configureRelayerKeys adds the chain configuration to the relayer
+// and adds the preconfigured key to the relayer for each of the given relayer-chains.
+func (ic *Interchain) configureRelayerKeys(ctx context.Context, rep *testreporter.RelayerExecReporter, relayerWallets map[relayerChain]ibc.Wallet) error {
 	// Possible optimization: each relayer could be configured concurrently.
 	// But we are only testing with a single relayer so far, so we don't need this yet.
 
-	for r, chains := range ic.relayerChains() {
-		for _, c := range chains {
-			rpcAddr, grpcAddr := c.GetRPCAddress(), c.GetGRPCAddress()
-			if !r.UseDockerNetwork() {
-				rpcAddr, grpcAddr = c.GetHostRPCAddress(), c.GetHostGRPCAddress()
-			}
+	for rc, wallet := range relayerWallets {
+		r, c := rc.R, rc.C
 
-			chainName := ic.chains[c]
-			if err := r.AddChainConfiguration(ctx,
-				rep,
-				c.Config(), chainName,
-				rpcAddr, grpcAddr,
-			); err != nil {
-				return fmt.Errorf("failed to configure relayer %s for chain %s: %w", ic.relayers[r], chainName, err)
-			}
+		rpcAddr, grpcAddr := c.GetRPCAddress(), c.GetGRPCAddress()
+		if !r.UseDockerNetwork() {
+			rpcAddr, grpcAddr = c.GetHostRPCAddress(), c.GetHostGRPCAddress()
+		}
 
-			if err := r.RestoreKey(ctx,
-				rep,
-				c.Config(), chainName,
-				ic.relayerWallets[relayerChain{R: r, C: c}].Mnemonic(),
-			); err != nil {
-				return fmt.Errorf("failed to restore key to relayer %s for chain %s: %w", ic.relayers[r], chainName, err)
-			}
+		chainName := ic.chains[c]
+		if err := r.AddChainConfiguration(ctx,
+			rep,
+			c.Config(), chainName,
+			rpcAddr, grpcAddr,
+		); err != nil {
+			return fmt.Errorf("failed to configure relayer %s for chain %s: %w", ic.relayers[r], chainName, err)
+		}
+
+		if err := r.RestoreKey(ctx,
+			rep,
+			c.Config(), chainName,
+			wallet.Mnemonic(),
+		); err != nil {
+			return fmt.Errorf("failed to restore key to relayer %s for chain %s: %w", ic.relayers[r], chainName, err)
 		}
 	}
 
@@ -461,3 +461,206 @@ func assertTransactionIsValid(t *testing.T, resp sdk.TxResponse) {
 	require.NotEmpty(t, resp.TxHash)
 	require.NotEmpty(t, resp.Events)
 }
+
+func TestInterchain_BuildHooks(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	client, network := interchaintest.DockerSetup(t)
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "g1", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0"}},
+		{Name: "gaia", ChainName: "g2", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-1"}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	gaia0, gaia1 := chains[0], chains[1]
+
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(
+		t, client, network,
+	)
+
+	const pathName = "p"
+	clientOpts := ibc.CreateClientOptions{TrustingPeriod: "330h"}
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia0).
+		AddChain(gaia1).
+		AddRelayer(r, "r").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:           gaia0,
+			Chain2:           gaia1,
+			Relayer:          r,
+			Path:             pathName,
+			CreateClientOpts: clientOpts,
+		})
+	defer ic.Close()
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	genesisAddr := sdk.MustBech32ifyAddressBytes(gaia0.Config().Bech32Prefix, make([]byte, 20))
+	genesisAmount := math.NewInt(12_345)
+
+	// Link hooks are called from other goroutines, so their arguments are checked once Build returns.
+	var (
+		calls     []string
+		hookLinks []interchaintest.InterchainLink
+	)
+	buildHook := func(name string) interchaintest.BuildHookFunc {
+		return func(_ context.Context, chains []ibc.Chain, relayers []ibc.Relayer) error {
+			require.Equal(t, []ibc.Chain{gaia0, gaia1}, chains)
+			require.Equal(t, []ibc.Relayer{r}, relayers)
+			calls = append(calls, name)
+			return nil
+		}
+	}
+	linkHook := func(name string) interchaintest.LinkHookFunc {
+		return func(_ context.Context, link interchaintest.InterchainLink) error {
+			calls = append(calls, name)
+			hookLinks = append(hookLinks, link)
+			return nil
+		}
+	}
+
+	ctx := context.Background()
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+
+		AfterInitialize: buildHook("AfterInitialize"),
+		BeforeChainsStart: func(_ context.Context, chains []ibc.Chain, relayers []ibc.Relayer, walletAmounts map[ibc.Chain][]ibc.WalletAmount) error {
+			require.Equal(t, []ibc.Chain{gaia0, gaia1}, chains)
+			require.Equal(t, []ibc.Relayer{r}, relayers)
+			// The faucet is always funded in genesis.
+			require.NotEmpty(t, walletAmounts[gaia0])
+			walletAmounts[gaia0] = append(walletAmounts[gaia0], ibc.WalletAmount{
+				Address: genesisAddr,
+				Denom:   gaia0.Config().Denom,
+				Amount:  genesisAmount,
+			})
+			calls = append(calls, "BeforeChainsStart")
+			return nil
+		},
+		AfterChainsStart: buildHook("AfterChainsStart"),
+		BeforeLink:       linkHook("BeforeLink"),
+		AfterLink:        linkHook("AfterLink"),
+		AfterBuild:       buildHook("AfterBuild"),
+	}))
+
+	require.Equal(t, []string{
+		"AfterInitialize", "BeforeChainsStart", "AfterChainsStart", "BeforeLink", "AfterLink", "AfterBuild",
+	}, calls)
+
+	// Both link hooks get the link with its options, defaulted where they were not set.
+	wantLink := interchaintest.InterchainLink{
+		Chain1:            gaia0,
+		Chain2:            gaia1,
+		Relayer:           r,
+		Path:              pathName,
+		CreateClientOpts:  clientOpts,
+		CreateChannelOpts: ibc.DefaultChannelOpts(),
+	}
+	require.Equal(t, []interchaintest.InterchainLink{wantLink, wantLink}, hookLinks)
+
+	bal, err := gaia0.GetBalance(ctx, genesisAddr, gaia0.Config().Denom)
+	require.NoError(t, err)
+	require.True(t, bal.Equal(genesisAmount))
+}
+
+func TestInterchain_Extend(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	client, network := interchaintest.DockerSetup(t)
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "g1", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0"}},
+		{Name: "gaia", ChainName: "g2", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-1"}},
+		{Name: "gaia", ChainName: "g3", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-2"}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	gaia0, gaia1, gaia2 := chains[0], chains[1], chains[2]
+
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(
+		t, client, network,
+	)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia0).
+		AddChain(gaia1).
+		AddRelayer(r, "r").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia0,
+			Chain2:  gaia1,
+			Relayer: r,
+			Path:    "g1-g2",
+		})
+	defer ic.Close()
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	ctx := context.Background()
+	buildOpts := interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}
+	require.NoError(t, ic.Build(ctx, eRep, buildOpts))
+
+	heightBefore, err := gaia0.Height(ctx)
+	require.NoError(t, err)
+
+	// Join a new chain to the first one, with the same relayer.
+	ic.AddChain(gaia2).AddLink(interchaintest.InterchainLink{
+		Chain1:  gaia0,
+		Chain2:  gaia2,
+		Relayer: r,
+		Path:    "g1-g3",
+	})
+
+	var linked []string
+	buildOpts.AfterLink = func(_ context.Context, link interchaintest.InterchainLink) error {
+		linked = append(linked, link.Path)
+		return nil
+	}
+	require.NoError(t, ic.Extend(ctx, eRep, buildOpts))
+
+	// Only the new link was created.
+	require.Equal(t, []string{"g1-g3"}, linked)
+
+	// The first chain kept running, with a channel to each of the other chains.
+	heightAfter, err := gaia0.Height(ctx)
+	require.NoError(t, err)
+	require.Greater(t, heightAfter, heightBefore)
+
+	channels, err := r.GetChannels(ctx, eRep, gaia0.Config().ChainID)
+	require.NoError(t, err)
+	require.Len(t, channels, 2)
+
+	channels, err = r.GetChannels(ctx, eRep, gaia2.Config().ChainID)
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+
+	// The new chain has a faucet to fund users.
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", 10_000_000, gaia2)
+	bal, err := gaia2.GetBalance(ctx, users[0].FormattedAddress(), gaia2.Config().Denom)
+	require.NoError(t, err)
+	require.True(t, bal.Equal(math.NewInt(10_000_000)))
+
+	require.Panics(t, func() {
+		_ = interchaintest.NewInterchain().Extend(ctx, eRep, buildOpts)
+	})
+}
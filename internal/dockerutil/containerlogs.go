@@ -0,0 +1,85 @@
+package dockerutil
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// SaveContainerLogsOnFailure determines whether the logs of the containers associated with a test
+// using DockerSetup are saved to files following a test failure, before the containers are removed.
+//
+// The value is true by default, but can be initialized to false by setting the
+// environment variable IBCTEST_SKIP_CONTAINER_LOGS to a non-empty value.
+// Alternatively, importers of the dockerutil package may set the variable to false.
+// Because dockerutil is an internal package, the public API for setting this value
+// is interchaintest.SaveContainerLogsOnFailure(bool).
+var SaveContainerLogsOnFailure = os.Getenv("IBCTEST_SKIP_CONTAINER_LOGS") == ""
+
+// ContainerLogsDir is the directory in which a directory of container logs is created for each failed test.
+//
+// The value is the system temporary directory by default, but can be initialized by setting the
+// environment variable IBCTEST_CONTAINER_LOGS_DIR, e.g. to a directory collected as a CI artifact.
+// The public API for setting this value is interchaintest.ContainerLogsDir(string).
+var ContainerLogsDir = os.Getenv("IBCTEST_CONTAINER_LOGS_DIR")
+
+// saveContainerLogs writes the stdout and stderr of each container to a file named after the container,
+// in a new directory named after the test, and returns the directory.
+func saveContainerLogs(ctx context.Context, t DockerSetupTestingT, cli *client.Client, cs []types.Container) (string, error) {
+	if ContainerLogsDir != "" {
+		if err := os.MkdirAll(ContainerLogsDir, 0o755); err != nil {
+			return "", fmt.Errorf("creating container logs directory: %w", err)
+		}
+	}
+
+	dir, err := os.MkdirTemp(ContainerLogsDir, SanitizeContainerName(t.Name())+"-container-logs-")
+	if err != nil {
+		return "", fmt.Errorf("creating container logs directory: %w", err)
+	}
+
+	for _, c := range cs {
+		name := c.ID
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+
+		if err := saveContainerLog(ctx, cli, c.ID, filepath.Join(dir, name+".log")); err != nil {
+			t.Logf("Failed to save logs of container %s: %v", name, err)
+		}
+	}
+
+	return dir, nil
+}
+
+// saveContainerLog writes the interleaved stdout and stderr of the container to the file at path.
+func saveContainerLog(ctx context.Context, cli *client.Client, containerID, path string) (err error) {
+	rc, err := cli.ContainerLogs(ctx, containerID, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Timestamps: true,
+	})
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	// Containers are created without a TTY, so the logs are multiplexed.
+	_, err = stdcopy.StdCopy(f, f, rc)
+	return err
+}
@@ -108,6 +108,15 @@ func dockerCleanup(t DockerSetupTestingT, cli *client.Client) func() {
 			return
 		}
 
+		if t.Failed() && SaveContainerLogsOnFailure && len(cs) > 0 {
+			dir, err := saveContainerLogs(ctx, t, cli, cs)
+			if err != nil {
+				t.Logf("Failed to save container logs during docker cleanup: %v", err)
+			} else {
+				t.Logf("Saved container logs to %s", dir)
+			}
+		}
+
 		for _, c := range cs {
 			if (t.Failed() && showContainerLogs == "") || showContainerLogs == "always" {
 				logTail := "50"
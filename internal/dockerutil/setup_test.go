@@ -3,13 +3,17 @@ package dockerutil_test
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/errdefs"
 	"github.com/strangelove-ventures/interchaintest/v8/internal/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v8/internal/mocktesting"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
 )
 
 func TestDockerSetup_KeepVolumes(t *testing.T) {
@@ -78,3 +82,69 @@ func TestDockerSetup_KeepVolumes(t *testing.T) {
 		})
 	}
 }
+
+func TestDockerSetup_SaveContainerLogs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+
+	origSave, origDir := dockerutil.SaveContainerLogsOnFailure, dockerutil.ContainerLogsDir
+	defer func() {
+		dockerutil.SaveContainerLogsOnFailure, dockerutil.ContainerLogsDir = origSave, origDir
+	}()
+
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		save      bool
+		passed    bool
+		logsSaved bool
+	}{
+		{save: true, passed: false, logsSaved: true},
+		{save: false, passed: false, logsSaved: false},
+		{save: true, passed: true, logsSaved: false},
+	} {
+		tc := tc
+		state := "failed"
+		if tc.passed {
+			state = "passed"
+		}
+
+		testName := fmt.Sprintf("save=%t, test %s", tc.save, state)
+		t.Run(testName, func(t *testing.T) {
+			dockerutil.SaveContainerLogsOnFailure = tc.save
+			dockerutil.ContainerLogsDir = t.TempDir()
+			mt := mocktesting.NewT(t.Name())
+
+			var containerName string
+			mt.Simulate(func() {
+				cli, network := dockerutil.DockerSetup(mt)
+
+				img := dockerutil.NewImage(zaptest.NewLogger(t), cli, network, mt.Name(), "busybox", "stable")
+				c, err := img.Start(ctx, []string{"sh", "-c", "echo hello && echo oops >&2 && sleep 600"}, dockerutil.ContainerOptions{})
+				require.NoError(t, err)
+				containerName = c.Name
+
+				// Give the container a moment to log before the test ends.
+				time.Sleep(time.Second)
+
+				if !tc.passed {
+					mt.Fail()
+				}
+			})
+
+			logFiles, err := filepath.Glob(filepath.Join(dockerutil.ContainerLogsDir, "*", containerName+".log"))
+			require.NoError(t, err)
+			if !tc.logsSaved {
+				require.Empty(t, logFiles)
+				return
+			}
+
+			require.Len(t, logFiles, 1)
+			bz, err := os.ReadFile(logFiles[0])
+			require.NoError(t, err)
+			require.Contains(t, string(bz), "hello")
+			require.Contains(t, string(bz), "oops")
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package dockerutil
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"go.uber.org/zap"
+)
+
+// VolumeArchiver allows saving the entire content of a Docker volume to a tar archive,
+// and restoring that content into another volume.
+type VolumeArchiver struct {
+	log *zap.Logger
+
+	cli *client.Client
+
+	testName string
+}
+
+// NewVolumeArchiver returns a new VolumeArchiver.
+func NewVolumeArchiver(log *zap.Logger, cli *client.Client, testName string) *VolumeArchiver {
+	return &VolumeArchiver{log: log, cli: cli, testName: testName}
+}
+
+// The volume is mounted at a fixed path, so that archive entries are all relative to the volume root.
+const volumeArchiveMountPath = "/mnt/dockervolume"
+
+// Archive writes a tar archive of the content of the volume specified by volumeName to w.
+// The archive preserves file ownership and permissions.
+//
+// The volume should not be in use by a running container while it is archived.
+func (a *VolumeArchiver) Archive(ctx context.Context, volumeName string, w io.Writer) error {
+	if err := ensureBusybox(ctx, a.cli); err != nil {
+		return err
+	}
+
+	containerName := fmt.Sprintf("interchaintest-archivevolume-%d-%s", time.Now().UnixNano(), RandLowerCaseLetterString(5))
+
+	cc, err := a.cli.ContainerCreate(
+		ctx,
+		&container.Config{
+			Image: busyboxRef,
+
+			// Use root user to avoid permission issues when reading files from the volume.
+			User: GetRootUserString(),
+
+			Labels: map[string]string{CleanupLabel: a.testName},
+		},
+		&container.HostConfig{
+			Binds:      []string{volumeName + ":" + volumeArchiveMountPath},
+			AutoRemove: true,
+		},
+		nil, // No networking necessary.
+		nil,
+		containerName,
+	)
+	if err != nil {
+		return fmt.Errorf("creating container: %w", err)
+	}
+
+	defer func() {
+		if err := a.cli.ContainerRemove(ctx, cc.ID, types.ContainerRemoveOptions{
+			Force: true,
+		}); err != nil {
+			a.log.Warn("Failed to remove archive volume container", zap.String("container_id", cc.ID), zap.Error(err))
+		}
+	}()
+
+	rc, _, err := a.cli.CopyFromContainer(ctx, cc.ID, volumeArchiveMountPath)
+	if err != nil {
+		return fmt.Errorf("copying from container: %w", err)
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	if _, err := io.Copy(w, rc); err != nil {
+		return fmt.Errorf("writing volume archive: %w", err)
+	}
+
+	return nil
+}
+
+// Restore extracts the tar archive read from r, as written by Archive, into the volume specified by volumeName.
+// Files already in the volume are kept, unless the archive contains a file with the same path.
+func (a *VolumeArchiver) Restore(ctx context.Context, volumeName string, r io.Reader) error {
+	if err := ensureBusybox(ctx, a.cli); err != nil {
+		return err
+	}
+
+	containerName := fmt.Sprintf("interchaintest-restorevolume-%d-%s", time.Now().UnixNano(), RandLowerCaseLetterString(5))
+
+	cc, err := a.cli.ContainerCreate(
+		ctx,
+		&container.Config{
+			Image: busyboxRef,
+
+			Entrypoint: []string{"sh", "-c"},
+			Cmd: []string{
+				// Take the uid and gid of the mount path,
+				// and set that as the owner of the restored files,
+				// in case they were not extracted with their original owner.
+				`chown -R "$(stat -c '%u:%g' "$1")" "$1"`,
+				"_", // Meaningless arg0 for sh -c with positional args.
+				volumeArchiveMountPath,
+			},
+
+			// Use root user to avoid permission issues when writing files to the volume.
+			User: GetRootUserString(),
+
+			Labels: map[string]string{CleanupLabel: a.testName},
+		},
+		&container.HostConfig{
+			Binds:      []string{volumeName + ":" + volumeArchiveMountPath},
+			AutoRemove: true,
+		},
+		nil, // No networking necessary.
+		nil,
+		containerName,
+	)
+	if err != nil {
+		return fmt.Errorf("creating container: %w", err)
+	}
+
+	autoRemoved := false
+	defer func() {
+		if autoRemoved {
+			// No need to attempt removing the container if we successfully started and waited for it to complete.
+			return
+		}
+
+		if err := a.cli.ContainerRemove(ctx, cc.ID, types.ContainerRemoveOptions{
+			Force: true,
+		}); err != nil {
+			a.log.Warn("Failed to remove restore volume container", zap.String("container_id", cc.ID), zap.Error(err))
+		}
+	}()
+
+	// Entries in the archive are prefixed with the base name of the mount path,
+	// so extract them into its parent directory.
+	if err := a.cli.CopyToContainer(
+		ctx,
+		cc.ID,
+		path.Dir(volumeArchiveMountPath),
+		r,
+		types.CopyToContainerOptions{},
+	); err != nil {
+		return fmt.Errorf("copying archive to container: %w", err)
+	}
+
+	if err := a.cli.ContainerStart(ctx, cc.ID, types.ContainerStartOptions{}); err != nil {
+		return fmt.Errorf("starting restore volume container: %w", err)
+	}
+
+	waitCh, errCh := a.cli.ContainerWait(ctx, cc.ID, container.WaitConditionNotRunning)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-errCh:
+		return err
+	case res := <-waitCh:
+		autoRemoved = true
+
+		if res.Error != nil {
+			return fmt.Errorf("waiting for restore volume container: %s", res.Error.Message)
+		}
+
+		if res.StatusCode != 0 {
+			return fmt.Errorf("chown on restored volume exited %d", res.StatusCode)
+		}
+	}
+
+	return nil
+}
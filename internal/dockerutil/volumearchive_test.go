@@ -0,0 +1,71 @@
+package dockerutil_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	volumetypes "github.com/docker/docker/api/types/volume"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/internal/dockerutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestVolumeArchiver(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+
+	t.Parallel()
+
+	cli, network := interchaintest.DockerSetup(t)
+
+	ctx := context.Background()
+	src, err := cli.VolumeCreate(ctx, volumetypes.CreateOptions{
+		Labels: map[string]string{dockerutil.CleanupLabel: t.Name()},
+	})
+	require.NoError(t, err)
+	dst, err := cli.VolumeCreate(ctx, volumetypes.CreateOptions{
+		Labels: map[string]string{dockerutil.CleanupLabel: t.Name()},
+	})
+	require.NoError(t, err)
+
+	img := dockerutil.NewImage(
+		zaptest.NewLogger(t),
+		cli,
+		network,
+		t.Name(),
+		"busybox", "stable",
+	)
+
+	res := img.Run(
+		ctx,
+		[]string{"sh", "-c", "printf 'hello world' > /mnt/test/hello.txt && mkdir -p /mnt/test/foo/bar/ && printf 'test' > /mnt/test/foo/bar/baz.txt"},
+		dockerutil.ContainerOptions{
+			Binds: []string{src.Name + ":/mnt/test"},
+			User:  dockerutil.GetRootUserString(),
+		},
+	)
+	require.NoError(t, res.Err)
+
+	va := dockerutil.NewVolumeArchiver(zaptest.NewLogger(t), cli, t.Name())
+
+	var buf bytes.Buffer
+	require.NoError(t, va.Archive(ctx, src.Name, &buf))
+	require.NoError(t, va.Restore(ctx, dst.Name, &buf))
+
+	fr := dockerutil.NewFileRetriever(zaptest.NewLogger(t), cli, t.Name())
+
+	t.Run("top-level file", func(t *testing.T) {
+		b, err := fr.SingleFileContent(ctx, dst.Name, "hello.txt")
+		require.NoError(t, err)
+		require.Equal(t, string(b), "hello world")
+	})
+
+	t.Run("nested file", func(t *testing.T) {
+		b, err := fr.SingleFileContent(ctx, dst.Name, "foo/bar/baz.txt")
+		require.NoError(t, err)
+		require.Equal(t, string(b), "test")
+	})
+}
@@ -0,0 +1,332 @@
+package interchaintest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v8/testreporter"
+)
+
+// InterchainManifest describes a built Interchain: its chains and their nodes, its relayers and the paths between the chains.
+// It is meant to be attached to test results, see Interchain.WriteManifest.
+type InterchainManifest struct {
+	Chains   []ChainManifest   `json:"chains"`
+	Relayers []RelayerManifest `json:"relayers"`
+	Paths    []PathManifest    `json:"paths"`
+}
+
+// ChainManifest describes a chain of an InterchainManifest.
+type ChainManifest struct {
+	Name    string `json:"name"`
+	ChainID string `json:"chain_id"`
+	Type    string `json:"type"`
+
+	// Addresses of the chain accessible by the host.
+	HostRPCAddress  string `json:"host_rpc_address"`
+	HostGRPCAddress string `json:"host_grpc_address"`
+	HostAPIAddress  string `json:"host_api_address,omitempty"`
+
+	// Nodes of the chain, validators first, reported for chains that list their node containers,
+	// such as cosmos and penumbra chains.
+	Nodes []NodeManifest `json:"nodes,omitempty"`
+
+	// Wallets funded in the chain's genesis, starting with the faucet.
+	GenesisWallets []WalletManifest `json:"genesis_wallets"`
+}
+
+// NodeManifest describes a node of a ChainManifest.
+type NodeManifest struct {
+	Validator bool `json:"validator"`
+
+	// Containers running the node, starting with the container of the node's main process.
+	Containers []ContainerManifest `json:"containers"`
+}
+
+// ContainerManifest describes a docker container running a node.
+type ContainerManifest struct {
+	Name string `json:"name"`
+
+	// Addresses accessible by the host of the published ports of the container, by container port, e.g. "26657/tcp".
+	HostPorts map[string]string `json:"host_ports"`
+}
+
+// WalletManifest describes a wallet funded in the genesis of a chain.
+type WalletManifest struct {
+	Address string `json:"address"`
+	Denom   string `json:"denom"`
+	Amount  string `json:"amount"`
+}
+
+// RelayerManifest describes a relayer of an InterchainManifest.
+type RelayerManifest struct {
+	Name string `json:"name"`
+
+	// Wallets used by the relayer on each chain it relays for. Mnemonics are omitted.
+	Wallets []RelayerWalletManifest `json:"wallets"`
+}
+
+// RelayerWalletManifest describes the wallet of a relayer on a chain.
+type RelayerWalletManifest struct {
+	ChainID string `json:"chain_id"`
+	KeyName string `json:"key_name"`
+	Address string `json:"address"`
+}
+
+// PathManifest describes a path of a relayer and the IBC connections between its chains.
+type PathManifest struct {
+	Relayer string `json:"relayer"`
+	Path    string `json:"path"`
+
+	Chain1ID string `json:"chain1_id"`
+	Chain2ID string `json:"chain2_id"`
+
+	// Connections between the chains of the path. There is usually a single connection,
+	// but every connection between the two chains is listed, since they cannot be attributed to a single path.
+	// Connections are empty if the path was not created.
+	Connections []ConnectionManifest `json:"connections"`
+}
+
+// ConnectionManifest describes an IBC connection between the chains of a PathManifest,
+// with the client and connection IDs on both chains.
+type ConnectionManifest struct {
+	Chain1 ConnectionEndManifest `json:"chain1"`
+	Chain2 ConnectionEndManifest `json:"chain2"`
+
+	Channels []ChannelManifest `json:"channels"`
+}
+
+// ConnectionEndManifest describes one end of a ConnectionManifest.
+type ConnectionEndManifest struct {
+	ClientID     string `json:"client_id"`
+	ConnectionID string `json:"connection_id"`
+}
+
+// ChannelManifest describes an IBC channel on a connection, with the port and channel IDs on both chains.
+type ChannelManifest struct {
+	Chain1 ibc.ChannelCounterparty `json:"chain1"`
+	Chain2 ibc.ChannelCounterparty `json:"chain2"`
+
+	State    string `json:"state"`
+	Ordering string `json:"ordering"`
+	Version  string `json:"version"`
+}
+
+// Manifest returns the manifest of the built Interchain.
+// The IDs of clients, connections and channels are queried through the relayer of each path.
+func (ic *Interchain) Manifest(ctx context.Context, rep *testreporter.RelayerExecReporter) (*InterchainManifest, error) {
+	if !ic.built {
+		return nil, fmt.Errorf("cannot create the manifest of an Interchain before it is built")
+	}
+
+	m := &InterchainManifest{
+		Chains:   []ChainManifest{},
+		Relayers: []RelayerManifest{},
+		Paths:    []PathManifest{},
+	}
+
+	for _, c := range ic.sortedChains() {
+		cm, err := ic.chainManifest(ctx, c)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe chain %s: %w", c.Config().Name, err)
+		}
+		m.Chains = append(m.Chains, cm)
+	}
+
+	relayerChains := ic.relayerChains()
+	for _, r := range ic.sortedRelayers() {
+		rm := RelayerManifest{
+			Name:    ic.relayers[r],
+			Wallets: []RelayerWalletManifest{},
+		}
+		for _, c := range relayerChains[r] {
+			w, ok := ic.relayerWallets[relayerChain{R: r, C: c}]
+			if !ok {
+				continue
+			}
+			rm.Wallets = append(rm.Wallets, RelayerWalletManifest{
+				ChainID: c.Config().ChainID,
+				KeyName: w.KeyName(),
+				Address: w.FormattedAddress(),
+			})
+		}
+		sort.Slice(rm.Wallets, func(i, j int) bool {
+			return rm.Wallets[i].ChainID < rm.Wallets[j].ChainID
+		})
+		m.Relayers = append(m.Relayers, rm)
+	}
+
+	for rp, link := range ic.links {
+		pm, err := pathManifest(ctx, rep, rp.Relayer, link.chains[0].Config().ChainID, link.chains[1].Config().ChainID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query path %s of relayer %s: %w", rp.Path, ic.relayers[rp.Relayer], err)
+		}
+		pm.Relayer = ic.relayers[rp.Relayer]
+		pm.Path = rp.Path
+		m.Paths = append(m.Paths, pm)
+	}
+	sort.Slice(m.Paths, func(i, j int) bool {
+		a, b := m.Paths[i], m.Paths[j]
+		if a.Relayer != b.Relayer {
+			return a.Relayer < b.Relayer
+		}
+		return a.Path < b.Path
+	})
+
+	return m, nil
+}
+
+// WriteManifest writes the manifest of the built Interchain, as indented JSON, to the file at path.
+func (ic *Interchain) WriteManifest(ctx context.Context, rep *testreporter.RelayerExecReporter, path string) error {
+	m, err := ic.Manifest(ctx, rep)
+	if err != nil {
+		return err
+	}
+
+	bz, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	if err := os.WriteFile(path, bz, 0o644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+func (ic *Interchain) chainManifest(ctx context.Context, c ibc.Chain) (ChainManifest, error) {
+	cfg := c.Config()
+	cm := ChainManifest{
+		Name:            cfg.Name,
+		ChainID:         cfg.ChainID,
+		Type:            cfg.Type,
+		HostRPCAddress:  c.GetHostRPCAddress(),
+		HostGRPCAddress: c.GetHostGRPCAddress(),
+		GenesisWallets:  []WalletManifest{},
+	}
+
+	if api, ok := c.(interface{ GetHostAPIAddress() string }); ok {
+		cm.HostAPIAddress = api.GetHostAPIAddress()
+	}
+
+	if nc, ok := c.(interface{ NodeContainers() []ibc.NodeContainers }); ok {
+		for _, n := range nc.NodeContainers() {
+			nm := NodeManifest{
+				Validator:  n.Validator,
+				Containers: []ContainerManifest{},
+			}
+			for _, name := range n.Containers {
+				container, err := ic.containerManifest(ctx, name)
+				if err != nil {
+					return cm, err
+				}
+				nm.Containers = append(nm.Containers, container)
+			}
+			cm.Nodes = append(cm.Nodes, nm)
+		}
+	}
+
+	for _, w := range ic.genesisWallets[c] {
+		cm.GenesisWallets = append(cm.GenesisWallets, WalletManifest{
+			Address: w.Address,
+			Denom:   w.Denom,
+			Amount:  w.Amount.String(),
+		})
+	}
+
+	return cm, nil
+}
+
+// containerManifest inspects the container with the given name for its published ports.
+func (ic *Interchain) containerManifest(ctx context.Context, name string) (ContainerManifest, error) {
+	cm := ContainerManifest{
+		Name:      name,
+		HostPorts: map[string]string{},
+	}
+
+	cjson, err := ic.cli.ContainerInspect(ctx, name)
+	if err != nil {
+		return cm, fmt.Errorf("failed to inspect container %s: %w", name, err)
+	}
+	if cjson.NetworkSettings == nil {
+		return cm, nil
+	}
+
+	for port := range cjson.NetworkSettings.Ports {
+		if addr := dockerutil.GetHostPort(cjson, string(port)); addr != "" {
+			cm.HostPorts[string(port)] = addr
+		}
+	}
+	return cm, nil
+}
+
+// pathManifest queries the connections and channels between the two chains through the relayer.
+// Like ibc.GetTransferChannel, connections are matched to the counterparty chain through the client they use.
+func pathManifest(ctx context.Context, rep *testreporter.RelayerExecReporter, r ibc.Relayer, chain1ID, chain2ID string) (PathManifest, error) {
+	pm := PathManifest{
+		Chain1ID:    chain1ID,
+		Chain2ID:    chain2ID,
+		Connections: []ConnectionManifest{},
+	}
+
+	clients, err := r.GetClients(ctx, rep, chain1ID)
+	if err != nil {
+		return pm, fmt.Errorf("failed to get clients on chain %s: %w", chain1ID, err)
+	}
+
+	clientIDs := make(map[string]struct{})
+	for _, client := range clients {
+		if client.ClientState.ChainID == chain2ID {
+			clientIDs[client.ClientID] = struct{}{}
+		}
+	}
+	if len(clientIDs) == 0 {
+		// The path was not created.
+		return pm, nil
+	}
+
+	connections, err := r.GetConnections(ctx, rep, chain1ID)
+	if err != nil {
+		return pm, fmt.Errorf("failed to get connections on chain %s: %w", chain1ID, err)
+	}
+
+	channels, err := r.GetChannels(ctx, rep, chain1ID)
+	if err != nil {
+		return pm, fmt.Errorf("failed to get channels on chain %s: %w", chain1ID, err)
+	}
+
+	for _, conn := range connections {
+		if _, ok := clientIDs[conn.ClientID]; !ok {
+			continue
+		}
+
+		cm := ConnectionManifest{
+			Chain1:   ConnectionEndManifest{ClientID: conn.ClientID, ConnectionID: conn.ID},
+			Channels: []ChannelManifest{},
+		}
+		if conn.Counterparty != nil {
+			cm.Chain2 = ConnectionEndManifest{ClientID: conn.Counterparty.ClientId, ConnectionID: conn.Counterparty.ConnectionId}
+		}
+
+		for _, ch := range channels {
+			if len(ch.ConnectionHops) != 1 || ch.ConnectionHops[0] != conn.ID {
+				continue
+			}
+			cm.Channels = append(cm.Channels, ChannelManifest{
+				Chain1:   ibc.ChannelCounterparty{PortID: ch.PortID, ChannelID: ch.ChannelID},
+				Chain2:   ch.Counterparty,
+				State:    ch.State,
+				Ordering: ch.Ordering,
+				Version:  ch.Version,
+			})
+		}
+
+		pm.Connections = append(pm.Connections, cm)
+	}
+
+	return pm, nil
+}
@@ -0,0 +1,108 @@
+package interchaintest_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestInterchain_Manifest(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	client, network := interchaintest.DockerSetup(t)
+
+	numValidators, numFullNodes := 1, 1
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "g1", Version: "v7.0.1", NumValidators: &numValidators, NumFullNodes: &numFullNodes, ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0"}},
+		{Name: "gaia", ChainName: "g2", Version: "v7.0.1", NumValidators: &numValidators, NumFullNodes: &numFullNodes, ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-1"}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	gaia0, gaia1 := chains[0], chains[1]
+
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(
+		t, client, network,
+	)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia0).
+		AddChain(gaia1).
+		AddRelayer(r, "r").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia0,
+			Chain2:  gaia1,
+			Relayer: r,
+			Path:    "p",
+		})
+	defer ic.Close()
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	ctx := context.Background()
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	require.NoError(t, ic.WriteManifest(ctx, eRep, path))
+
+	bz, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var m interchaintest.InterchainManifest
+	require.NoError(t, json.Unmarshal(bz, &m))
+
+	require.Len(t, m.Chains, 2)
+	for i, c := range []ibc.Chain{gaia0, gaia1} {
+		cm := m.Chains[i]
+		require.Equal(t, c.Config().Name, cm.Name)
+		require.Equal(t, c.Config().ChainID, cm.ChainID)
+		require.Equal(t, c.GetHostRPCAddress(), cm.HostRPCAddress)
+		require.Len(t, cm.Nodes, 2)
+		require.True(t, cm.Nodes[0].Validator)
+		require.False(t, cm.Nodes[1].Validator)
+		for _, n := range cm.Nodes {
+			require.Len(t, n.Containers, 1)
+			require.NotEmpty(t, n.Containers[0].Name)
+			require.NotEmpty(t, n.Containers[0].HostPorts["26657/tcp"])
+			require.NotEmpty(t, n.Containers[0].HostPorts["9090/tcp"])
+		}
+
+		// The faucet and the relayer wallet.
+		require.Len(t, cm.GenesisWallets, 2)
+	}
+
+	require.Len(t, m.Relayers, 1)
+	require.Equal(t, "r", m.Relayers[0].Name)
+	require.Len(t, m.Relayers[0].Wallets, 2)
+	require.Equal(t, m.Chains[1].GenesisWallets[1].Address, m.Relayers[0].Wallets[1].Address)
+
+	require.Len(t, m.Paths, 1)
+	pm := m.Paths[0]
+	require.Equal(t, "p", pm.Path)
+	require.Len(t, pm.Connections, 1)
+	require.Len(t, pm.Connections[0].Channels, 1)
+
+	channel, err := ibc.GetTransferChannel(ctx, r, eRep, gaia1.Config().ChainID, gaia0.Config().ChainID)
+	require.NoError(t, err)
+	require.Equal(t, channel.ChannelID, pm.Connections[0].Channels[0].Chain2.ChannelID)
+	require.Equal(t, channel.Counterparty.ChannelID, pm.Connections[0].Channels[0].Chain1.ChannelID)
+	require.Equal(t, channel.ConnectionHops[0], pm.Connections[0].Chain2.ConnectionID)
+}
@@ -494,6 +494,11 @@ func (r *DockerRelayer) Bind() []string {
 	return []string{r.volumeName + ":" + r.HomeDir()}
 }
 
+// VolumeName returns the name of the Docker volume holding the home directory of the relayer.
+func (r *DockerRelayer) VolumeName() string {
+	return r.volumeName
+}
+
 // HomeDir returns the home directory of the relayer on the underlying Docker container's filesystem.
 func (r *DockerRelayer) HomeDir() string {
 	return r.homeDir
@@ -0,0 +1,533 @@
+package interchaintest
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/internal/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer/rly"
+	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// snapshotManifestFile is the name of the file describing a snapshot, inside the snapshot directory.
+const snapshotManifestFile = "manifest.json"
+
+// rlyConfigFile is the path of the cosmos/relayer config file, relative to the relayer home directory.
+const rlyConfigFile = "config/config.yaml"
+
+// interchainSnapshot is the manifest of an Interchain snapshot,
+// recording what is needed to bring the archived volumes back up in another test.
+type interchainSnapshot struct {
+	Chains         []chainSnapshot
+	Relayers       []relayerSnapshot
+	RelayerWallets []relayerWalletSnapshot
+}
+
+type chainSnapshot struct {
+	Name string
+
+	// Addresses of the chain in the Docker network when it was snapshotted,
+	// which are replaced in the relayer configs on restore.
+	RPCAddress, GRPCAddress string
+
+	// Archive files of the node volumes, in the order of CosmosChain.Nodes.
+	Nodes []string
+
+	// Wallets funded in the chain's genesis.
+	GenesisWallets []ibc.WalletAmount
+}
+
+type relayerSnapshot struct {
+	Name    string
+	Archive string
+
+	// Wallets known to the relayer, keyed by chain ID.
+	Wallets map[string]walletSnapshot
+}
+
+type relayerWalletSnapshot struct {
+	Relayer, Chain string
+	Wallet         walletSnapshot
+}
+
+type walletSnapshot struct {
+	KeyName          string
+	Address          []byte
+	FormattedAddress string
+	Mnemonic         string
+}
+
+func newWalletSnapshot(w ibc.Wallet) walletSnapshot {
+	return walletSnapshot{
+		KeyName:          w.KeyName(),
+		Address:          w.Address(),
+		FormattedAddress: w.FormattedAddress(),
+		Mnemonic:         w.Mnemonic(),
+	}
+}
+
+// Snapshot stops the chains of the built Interchain, archives the volumes of every node and relayer
+// along with the relayer wallets into a new directory within dir, named after SnapshotKey, then restarts the chains.
+// The chains are restarted even if the snapshot fails after they were stopped, and restart errors are returned along with its error.
+// Build restores the snapshot instead of building the Interchain from scratch when InterchainBuildOptions.SnapshotDir is dir.
+//
+// Only cosmos chains and the cosmos/relayer are supported. The relayers must not be running.
+func (ic *Interchain) Snapshot(ctx context.Context, dir string) (err error) {
+	if !ic.built {
+		return fmt.Errorf("cannot snapshot an Interchain before it is built")
+	}
+
+	key, err := ic.SnapshotKey()
+	if err != nil {
+		return err
+	}
+
+	chains, err := ic.snapshotChains()
+	if err != nil {
+		return err
+	}
+	relayers, err := ic.snapshotRelayers()
+	if err != nil {
+		return err
+	}
+
+	// Write the snapshot to a temporary directory first,
+	// so that an interrupted snapshot is never mistaken for a complete one.
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	tmpDir, err := os.MkdirTemp(dir, ".snapshot-")
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	va := dockerutil.NewVolumeArchiver(ic.log, ic.cli, ic.testName)
+
+	var snapshot interchainSnapshot
+	for _, r := range relayers {
+		name := ic.relayers[r]
+		rs := relayerSnapshot{
+			Name:    name,
+			Archive: "relayer-" + name + ".tar.gz",
+			Wallets: make(map[string]walletSnapshot),
+		}
+		for _, c := range ic.relayerChains()[r] {
+			chainID := c.Config().ChainID
+			if w, ok := r.GetWallet(chainID); ok {
+				rs.Wallets[chainID] = newWalletSnapshot(w)
+			}
+		}
+		if err := archiveVolume(ctx, va, r.VolumeName(), filepath.Join(tmpDir, rs.Archive)); err != nil {
+			return fmt.Errorf("failed to archive relayer %s: %w", name, err)
+		}
+		snapshot.Relayers = append(snapshot.Relayers, rs)
+	}
+
+	for rc, w := range ic.relayerWallets {
+		snapshot.RelayerWallets = append(snapshot.RelayerWallets, relayerWalletSnapshot{
+			Relayer: ic.relayers[rc.R],
+			Chain:   rc.C.Config().Name,
+			Wallet:  newWalletSnapshot(w),
+		})
+	}
+	sort.Slice(snapshot.RelayerWallets, func(i, j int) bool {
+		a, b := snapshot.RelayerWallets[i], snapshot.RelayerWallets[j]
+		return a.Relayer+"/"+a.Chain < b.Relayer+"/"+b.Chain
+	})
+
+	// Stop every chain before archiving any of them, so that the archived chains agree on the state of their IBC clients.
+	// Once stopping has begun, every chain is restarted on return, even if stopping or archiving failed.
+	defer func() {
+		var eg errgroup.Group
+		for _, c := range chains {
+			c := c
+			eg.Go(func() error {
+				if err := c.StartAllNodes(ctx); err != nil {
+					return fmt.Errorf("failed to restart chain %s: %w", c.Config().Name, err)
+				}
+				return testutil.WaitForBlocks(ctx, 2, c)
+			})
+		}
+		multierr.AppendInto(&err, eg.Wait())
+	}()
+
+	var eg errgroup.Group
+	for _, c := range chains {
+		c := c
+		eg.Go(func() error {
+			if err := c.StopAllNodes(ctx); err != nil {
+				return fmt.Errorf("failed to stop chain %s: %w", c.Config().Name, err)
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	for _, c := range chains {
+		cs := chainSnapshot{
+			Name:           c.Config().Name,
+			RPCAddress:     c.GetRPCAddress(),
+			GRPCAddress:    c.GetGRPCAddress(),
+			GenesisWallets: ic.genesisWallets[c],
+		}
+		for _, n := range c.Nodes() {
+			archive := n.Name() + ".tar.gz"
+			if err := archiveVolume(ctx, va, n.VolumeName, filepath.Join(tmpDir, archive)); err != nil {
+				return fmt.Errorf("failed to archive node %s: %w", n.Name(), err)
+			}
+			cs.Nodes = append(cs.Nodes, archive)
+		}
+		snapshot.Chains = append(snapshot.Chains, cs)
+	}
+
+	bz, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, snapshotManifestFile), bz, 0o644); err != nil {
+		return fmt.Errorf("failed to write snapshot manifest: %w", err)
+	}
+
+	snapshotDir := filepath.Join(dir, key)
+	if err := os.RemoveAll(snapshotDir); err != nil {
+		return fmt.Errorf("failed to remove previous snapshot: %w", err)
+	}
+	if err := os.Rename(tmpDir, snapshotDir); err != nil {
+		return fmt.Errorf("failed to move snapshot into place: %w", err)
+	}
+
+	return nil
+}
+
+// SnapshotKey returns the key under which snapshots of the Interchain are stored:
+// a hash of its chains, relayers and links, as declared when building the Interchain.
+//
+// The hooks of chain configs, such as ModifyGenesis, are not part of the key.
+// Use a different snapshot directory when changing them.
+func (ic *Interchain) SnapshotKey() (string, error) {
+	type chainKey struct {
+		Type, Name, ChainID      string
+		Images                   []ibc.DockerImage
+		Bin, Bech32Prefix        string
+		Denom, CoinType          string
+		GasPrices                string
+		NoHostMount, SkipGenTx   bool
+		ConfigFileOverrides      map[string]any
+		Validators, FullNodes    int
+		AdditionalGenesisWallets []ibc.WalletAmount
+	}
+	type relayerKey struct {
+		Name  string
+		Image ibc.DockerImage
+	}
+	type linkKey struct {
+		Relayer, Path     string
+		Chain1, Chain2    string
+		CreateClientOpts  ibc.CreateClientOptions
+		CreateChannelOpts ibc.CreateChannelOptions
+	}
+	var key struct {
+		Chains   []chainKey
+		Relayers []relayerKey
+		Links    []linkKey
+	}
+
+	for _, c := range ic.sortedChains() {
+		cfg := c.Config()
+		ck := chainKey{
+			Type:                     cfg.Type,
+			Name:                     cfg.Name,
+			ChainID:                  cfg.ChainID,
+			Images:                   cfg.Images,
+			Bin:                      cfg.Bin,
+			Bech32Prefix:             cfg.Bech32Prefix,
+			Denom:                    cfg.Denom,
+			CoinType:                 cfg.CoinType,
+			GasPrices:                cfg.GasPrices,
+			NoHostMount:              cfg.NoHostMount,
+			SkipGenTx:                cfg.SkipGenTx,
+			ConfigFileOverrides:      cfg.ConfigFileOverrides,
+			AdditionalGenesisWallets: ic.AdditionalGenesisWallets[c],
+		}
+		if cc, ok := c.(*cosmos.CosmosChain); ok {
+			ck.Validators, ck.FullNodes = len(cc.Validators), len(cc.FullNodes)
+		}
+		key.Chains = append(key.Chains, ck)
+	}
+
+	for _, r := range ic.sortedRelayers() {
+		rk := relayerKey{Name: ic.relayers[r]}
+		if img, ok := r.(interface{ ContainerImage() ibc.DockerImage }); ok {
+			rk.Image = img.ContainerImage()
+		}
+		key.Relayers = append(key.Relayers, rk)
+	}
+
+	for rp, link := range ic.links {
+		key.Links = append(key.Links, linkKey{
+			Relayer:           ic.relayers[rp.Relayer],
+			Path:              rp.Path,
+			Chain1:            link.chains[0].Config().Name,
+			Chain2:            link.chains[1].Config().Name,
+			CreateClientOpts:  link.createClientOpts,
+			CreateChannelOpts: link.createChannelOpts,
+		})
+	}
+	sort.Slice(key.Links, func(i, j int) bool {
+		a, b := key.Links[i], key.Links[j]
+		return a.Relayer+"/"+a.Path < b.Relayer+"/"+b.Path
+	})
+
+	bz, err := json.Marshal(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode snapshot key: %w", err)
+	}
+	sum := sha256.Sum256(bz)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// validateSnapshotOptions reports, before anything is built, whether the Interchain supports snapshots
+// and whether opts set hooks that restoring a snapshot would skip.
+func (ic *Interchain) validateSnapshotOptions(opts InterchainBuildOptions) error {
+	if _, err := ic.snapshotChains(); err != nil {
+		return err
+	}
+	if _, err := ic.snapshotRelayers(); err != nil {
+		return err
+	}
+
+	var hooks []string
+	if opts.BeforeChainsStart != nil {
+		hooks = append(hooks, "BeforeChainsStart")
+	}
+	if opts.BeforeLink != nil {
+		hooks = append(hooks, "BeforeLink")
+	}
+	if opts.AfterLink != nil {
+		hooks = append(hooks, "AfterLink")
+	}
+	if len(hooks) > 0 {
+		return fmt.Errorf("the %s hooks cannot be used with SnapshotDir, since restoring a snapshot skips them", strings.Join(hooks, ", "))
+	}
+	return nil
+}
+
+// restoreSnapshot starts the chains and configures the relayers of the Interchain from the snapshot
+// of the same topology in opts.SnapshotDir, after the chains have been initialized.
+// It reports false, without changing anything, if there is no such snapshot.
+func (ic *Interchain) restoreSnapshot(ctx context.Context, opts InterchainBuildOptions) (bool, error) {
+	key, err := ic.SnapshotKey()
+	if err != nil {
+		return false, err
+	}
+
+	snapshotDir := filepath.Join(opts.SnapshotDir, key)
+	bz, err := os.ReadFile(filepath.Join(snapshotDir, snapshotManifestFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read snapshot manifest: %w", err)
+	}
+
+	var snapshot interchainSnapshot
+	if err := json.Unmarshal(bz, &snapshot); err != nil {
+		return false, fmt.Errorf("failed to decode snapshot manifest %s: %w", snapshotDir, err)
+	}
+
+	ic.log.Info("Restoring Interchain from snapshot", zap.String("snapshot", snapshotDir))
+
+	chains, err := ic.snapshotChains()
+	if err != nil {
+		return false, err
+	}
+	relayers, err := ic.snapshotRelayers()
+	if err != nil {
+		return false, err
+	}
+
+	va := dockerutil.NewVolumeArchiver(ic.log, ic.cli, ic.testName)
+
+	chainSnapshots := make(map[string]chainSnapshot, len(snapshot.Chains))
+	for _, cs := range snapshot.Chains {
+		chainSnapshots[cs.Name] = cs
+	}
+
+	var eg errgroup.Group
+	for _, c := range chains {
+		c := c
+		cs, ok := chainSnapshots[c.Config().Name]
+		if !ok {
+			return false, fmt.Errorf("snapshot %s has no chain %s", snapshotDir, c.Config().Name)
+		}
+		nodes := c.Nodes()
+		if len(nodes) != len(cs.Nodes) {
+			return false, fmt.Errorf("snapshot %s has %d nodes for chain %s, expected %d", snapshotDir, len(cs.Nodes), cs.Name, len(nodes))
+		}
+
+		eg.Go(func() error {
+			for i, n := range nodes {
+				if err := restoreVolume(ctx, va, n.VolumeName, filepath.Join(snapshotDir, cs.Nodes[i])); err != nil {
+					return fmt.Errorf("failed to restore node %s: %w", n.Name(), err)
+				}
+			}
+			if err := c.StartFromVolumes(ctx); err != nil {
+				return fmt.Errorf("failed to start chain %s: %w", cs.Name, err)
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return false, err
+	}
+
+	// Pairs of old and new addresses of the chains, to point the relayers at the restored chains.
+	// The relayers use the Docker network, so the host addresses are not part of their configs.
+	var addresses []string
+	genesisWallets := make(map[ibc.Chain][]ibc.WalletAmount, len(chains))
+	for _, c := range chains {
+		cs := chainSnapshots[c.Config().Name]
+		addresses = append(addresses,
+			cs.RPCAddress, c.GetRPCAddress(),
+			cs.GRPCAddress, c.GetGRPCAddress(),
+		)
+		genesisWallets[c] = cs.GenesisWallets
+	}
+	ic.recordGenesisWallets(genesisWallets)
+	replacer := strings.NewReplacer(addresses...)
+
+	relayerSnapshots := make(map[string]relayerSnapshot, len(snapshot.Relayers))
+	for _, rs := range snapshot.Relayers {
+		relayerSnapshots[rs.Name] = rs
+	}
+
+	for _, r := range relayers {
+		name := ic.relayers[r]
+		rs, ok := relayerSnapshots[name]
+		if !ok {
+			return false, fmt.Errorf("snapshot %s has no relayer %s", snapshotDir, name)
+		}
+
+		if err := restoreVolume(ctx, va, r.VolumeName(), filepath.Join(snapshotDir, rs.Archive)); err != nil {
+			return false, fmt.Errorf("failed to restore relayer %s: %w", name, err)
+		}
+
+		config, err := r.ReadFileFromHomeDir(ctx, rlyConfigFile)
+		if err != nil {
+			return false, fmt.Errorf("failed to read config of relayer %s: %w", name, err)
+		}
+		if err := r.WriteFileToHomeDir(ctx, rlyConfigFile, []byte(replacer.Replace(string(config)))); err != nil {
+			return false, fmt.Errorf("failed to update config of relayer %s: %w", name, err)
+		}
+
+		for chainID, w := range rs.Wallets {
+			r.AddWallet(chainID, rly.NewWallet(w.KeyName, w.FormattedAddress, w.Mnemonic))
+		}
+	}
+
+	ic.relayerWallets = make(map[relayerChain]ibc.Wallet, len(snapshot.RelayerWallets))
+	for _, rw := range snapshot.RelayerWallets {
+		r, ok := ic.Relayer(rw.Relayer)
+		if !ok {
+			return false, fmt.Errorf("snapshot %s has a wallet for unknown relayer %s", snapshotDir, rw.Relayer)
+		}
+		c, ok := ic.Chain(rw.Chain)
+		if !ok {
+			return false, fmt.Errorf("snapshot %s has a wallet for unknown chain %s", snapshotDir, rw.Chain)
+		}
+		ic.relayerWallets[relayerChain{R: r, C: c}] = cosmos.NewWallet(rw.Wallet.KeyName, rw.Wallet.Address, rw.Wallet.Mnemonic, c.Config())
+	}
+
+	// The paths of every link were restored with the relayers.
+	ic.builtLinks = make(map[relayerPath]struct{}, len(ic.links))
+	for rp := range ic.links {
+		ic.builtLinks[rp] = struct{}{}
+	}
+
+	return true, nil
+}
+
+// snapshotChains returns the chains of the Interchain, ordered by name,
+// or an error if any of them does not support snapshots.
+func (ic *Interchain) snapshotChains() ([]*cosmos.CosmosChain, error) {
+	var chains []*cosmos.CosmosChain
+	for _, c := range ic.sortedChains() {
+		cc, ok := c.(*cosmos.CosmosChain)
+		if !ok {
+			return nil, fmt.Errorf("chain %s of type %s does not support snapshots", c.Config().Name, c.Config().Type)
+		}
+		if len(cc.Sidecars) > 0 {
+			return nil, fmt.Errorf("chain %s has sidecar processes, which do not support snapshots", c.Config().Name)
+		}
+		chains = append(chains, cc)
+	}
+	return chains, nil
+}
+
+// snapshotRelayers returns the relayers of the Interchain, ordered by name,
+// or an error if any of them does not support snapshots.
+func (ic *Interchain) snapshotRelayers() ([]*rly.CosmosRelayer, error) {
+	var relayers []*rly.CosmosRelayer
+	for _, r := range ic.sortedRelayers() {
+		cr, ok := r.(*rly.CosmosRelayer)
+		if !ok {
+			return nil, fmt.Errorf("relayer %s does not support snapshots", ic.relayers[r])
+		}
+		relayers = append(relayers, cr)
+	}
+	return relayers, nil
+}
+
+// archiveVolume writes a gzipped tar archive of the volume to the file at path.
+func archiveVolume(ctx context.Context, va *dockerutil.VolumeArchiver, volumeName, path string) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	zw := gzip.NewWriter(f)
+	if err := va.Archive(ctx, volumeName, zw); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// restoreVolume extracts the gzipped tar archive at path into the volume.
+func restoreVolume(ctx context.Context, va *dockerutil.VolumeArchiver, volumeName, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("failed to read archive %s: %w", path, err)
+	}
+	defer zr.Close()
+
+	return va.Restore(ctx, volumeName, zr)
+}
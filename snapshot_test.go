@@ -0,0 +1,170 @@
+package interchaintest_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/penumbra"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer/rly"
+	"github.com/strangelove-ventures/interchaintest/v8/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// snapshotInterchain returns an Interchain of two gaia chains linked by the relayer r,
+// declared identically on every call apart from the path name.
+func snapshotInterchain(t *testing.T, r ibc.Relayer, path string) (*interchaintest.Interchain, ibc.Chain) {
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "g1", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0"}},
+		{Name: "gaia", ChainName: "g2", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-1"}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	gaia0, gaia1 := chains[0], chains[1]
+
+	ic := interchaintest.NewInterchain().
+		AddChain(gaia0).
+		AddChain(gaia1).
+		AddRelayer(r, "r").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  gaia0,
+			Chain2:  gaia1,
+			Relayer: r,
+			Path:    path,
+		})
+
+	return ic, gaia0
+}
+
+// imageRelayer is a stand-in relayer that only reports its container image, which is all snapshot keys need.
+type imageRelayer struct {
+	ibc.Relayer
+}
+
+func (imageRelayer) ContainerImage() ibc.DockerImage {
+	return ibc.DockerImage{Repository: "ghcr.io/cosmos/relayer", Version: "v2.5.0", UidGid: "100:1000"}
+}
+
+func TestInterchain_SnapshotKey(t *testing.T) {
+	ic1, _ := snapshotInterchain(t, &imageRelayer{}, "p")
+	ic2, _ := snapshotInterchain(t, &imageRelayer{}, "p")
+	ic3, _ := snapshotInterchain(t, &imageRelayer{}, "other")
+
+	key1, err := ic1.SnapshotKey()
+	require.NoError(t, err)
+	key2, err := ic2.SnapshotKey()
+	require.NoError(t, err)
+	key3, err := ic3.SnapshotKey()
+	require.NoError(t, err)
+
+	require.Equal(t, key1, key2)
+	require.NotEqual(t, key1, key3)
+}
+
+func TestInterchain_SnapshotOptions(t *testing.T) {
+	ctx := context.Background()
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	// These errors are returned before Build needs Docker.
+	t.Run("unsupported chain", func(t *testing.T) {
+		pen := penumbra.NewPenumbraChain(zaptest.NewLogger(t), t.Name(), ibc.ChainConfig{
+			Type: "penumbra", Name: "penumbra", ChainID: "penumbra-0",
+		}, 1, 0)
+		err := interchaintest.NewInterchain().AddChain(pen).Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+			TestName:    t.Name(),
+			SnapshotDir: t.TempDir(),
+		})
+		require.ErrorContains(t, err, "chain penumbra of type penumbra does not support snapshots")
+	})
+
+	t.Run("unsupported relayer", func(t *testing.T) {
+		ic, _ := snapshotInterchain(t, &imageRelayer{}, "p")
+		err := ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+			TestName:    t.Name(),
+			SnapshotDir: t.TempDir(),
+		})
+		require.ErrorContains(t, err, "relayer r does not support snapshots")
+	})
+
+	t.Run("skipped hooks", func(t *testing.T) {
+		var r rly.CosmosRelayer
+		ic, _ := snapshotInterchain(t, &r, "p")
+		linkHook := func(context.Context, interchaintest.InterchainLink) error { return nil }
+		err := ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+			TestName:    t.Name(),
+			SnapshotDir: t.TempDir(),
+			BeforeLink:  linkHook,
+			AfterLink:   linkHook,
+		})
+		require.ErrorContains(t, err, "the BeforeLink, AfterLink hooks cannot be used with SnapshotDir")
+	})
+}
+
+func TestInterchain_SnapshotRestore(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	snapshotDir := t.TempDir()
+	ctx := context.Background()
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	build := func(t *testing.T) (ibc.Chain, ibc.Relayer) {
+		client, network := interchaintest.DockerSetup(t)
+
+		r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(
+			t, client, network,
+		)
+		ic, gaia0 := snapshotInterchain(t, r, "p")
+		t.Cleanup(func() { _ = ic.Close() })
+
+		require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+			TestName:  t.Name(),
+			Client:    client,
+			NetworkID: network,
+
+			SnapshotDir: snapshotDir,
+		}))
+		return gaia0, r
+	}
+
+	var channels []ibc.ChannelOutput
+	t.Run("build", func(t *testing.T) {
+		gaia0, r := build(t)
+
+		var err error
+		channels, err = r.GetChannels(ctx, eRep, gaia0.Config().ChainID)
+		require.NoError(t, err)
+		require.Len(t, channels, 1)
+
+		entries, err := os.ReadDir(snapshotDir)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		require.FileExists(t, filepath.Join(snapshotDir, entries[0].Name(), "manifest.json"))
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		gaia0, r := build(t)
+
+		// The channel created by the first build is back, and the relayer can still use it.
+		restoredChannels, err := r.GetChannels(ctx, eRep, gaia0.Config().ChainID)
+		require.NoError(t, err)
+		require.Equal(t, channels, restoredChannels)
+
+		_, ok := r.GetWallet(gaia0.Config().ChainID)
+		require.True(t, ok)
+
+		require.NoError(t, r.UpdateClients(ctx, eRep, "p"))
+	})
+}
@@ -30,6 +30,27 @@ func KeepDockerVolumesOnFailure(b bool) {
 	dockerutil.KeepVolumesOnFailure = b
 }
 
+// SaveContainerLogsOnFailure sets whether the logs of the containers associated with a particular test
+// are saved to files following a test failure, before the containers are removed.
+// The logs of each container are saved to a file named after the container,
+// in a directory named after the test, in the directory set with ContainerLogsDir.
+//
+// The value is true by default, but can be initialized to false by setting the
+// environment variable IBCTEST_SKIP_CONTAINER_LOGS to a non-empty value.
+// Alternatively, importers of the interchaintest package may call SaveContainerLogsOnFailure(false).
+func SaveContainerLogsOnFailure(b bool) {
+	dockerutil.SaveContainerLogsOnFailure = b
+}
+
+// ContainerLogsDir sets the directory in which the container logs of failed tests are saved.
+//
+// The value is the system temporary directory by default, but can be initialized by setting the
+// environment variable IBCTEST_CONTAINER_LOGS_DIR.
+// Alternatively, importers of the interchaintest package may call ContainerLogsDir(dir).
+func ContainerLogsDir(dir string) {
+	dockerutil.ContainerLogsDir = dir
+}
+
 // DockerSetup returns a new Docker Client and the ID of a configured network, associated with t.
 //
 // If any part of the setup fails, t.Fatal is called.
@@ -38,8 +38,8 @@ func GetAndFundTestUserWithMnemonic(
 		return nil, fmt.Errorf("failed to get funds from faucet: %w", err)
 	}
 
-	// If this chain is an instance of Penumbra we need to initialize a new pclientd instance for the
-	// newly created test user account.
+	// If this chain is an instance of Penumbra the test user account needs a pclientd instance.
+	// BuildWallet already created it on a running chain, in which case this is a nop.
 	err = CreatePenumbraClient(ctx, chain, keyName)
 	if err != nil {
 		return nil, err
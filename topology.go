@@ -0,0 +1,344 @@
+package interchaintest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"cosmossdk.io/math"
+	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// Topology is a declarative description of an Interchain: its chains, relayers and the links between them.
+// Topologies are written in YAML, or JSON as a subset of YAML, using the yaml field names of the Go types.
+// Chains are described by ChainSpecs, with the overrides of the built-in config under chain-config
+// and written like configuredChains.yaml:
+//
+//	chains:
+//	  - name: gaia
+//	    chain-name: gaia-1
+//	    version: v7.0.1
+//	    genesis-wallets:
+//	      - {address: cosmos1..., denom: uatom, amount: 1000000}
+//	  - name: osmosis
+//	    version: v11.0.1
+//	    chain-config: {chain-id: osmosis-1001, gas-prices: 0.0025uosmo}
+//	relayers:
+//	  - name: rly
+//	    impl: rly
+//	    startup-flags: ["-b", "100"]
+//	links:
+//	  - chain1: gaia-1
+//	    chain2: osmosis
+//	    relayer: rly
+//	    path: gaia-osmo
+//	    create-channel-opts: {source-port-name: transfer, dest-port-name: transfer, order: unordered, version: ics20-1}
+//
+// Use LoadTopology or ParseTopology to read a topology and Topology.Interchain to assemble the Interchain.
+type Topology struct {
+	Chains   []TopologyChain   `yaml:"chains"`
+	Relayers []TopologyRelayer `yaml:"relayers"`
+	Links    []TopologyLink    `yaml:"links"`
+}
+
+// TopologyChain describes a chain in a Topology.
+// Unlike other ChainSpecs, the chain name defaults to the spec Name without a suffix,
+// so it must be unique within the topology. The chain ID is still suffixed unless set explicitly.
+type TopologyChain struct {
+	*ChainSpec `yaml:",inline"`
+
+	// Wallets funded in the chain's genesis, in addition to the wallets created by the Interchain.
+	GenesisWallets []TopologyWallet `yaml:"genesis-wallets"`
+}
+
+// TopologyWallet is a genesis wallet of a TopologyChain.
+type TopologyWallet struct {
+	Address string `yaml:"address"`
+	Denom   string `yaml:"denom"`
+	// Amount is written as an integer, of any size.
+	Amount string `yaml:"amount"`
+}
+
+// TopologyRelayer describes a relayer in a Topology.
+type TopologyRelayer struct {
+	// Name of the relayer instance, referenced by links.
+	Name string `yaml:"name"`
+
+	// Impl selects the relayer implementation: "rly" (or "cosmos/relayer"), "hermes" or "hyperspace".
+	Impl string `yaml:"impl"`
+
+	// Optional overrides of the relayer options, see the relayer package.
+	Image        *ibc.DockerImage `yaml:"image"`
+	HomeDir      string           `yaml:"home-dir"`
+	StartupFlags []string         `yaml:"startup-flags"`
+	ImagePull    *bool            `yaml:"image-pull"`
+}
+
+// TopologyLink describes a link between two chains in a Topology.
+type TopologyLink struct {
+	// Names of the chains involved: their ChainName or, if that is not set, their Name.
+	Chain1 string `yaml:"chain1"`
+	Chain2 string `yaml:"chain2"`
+
+	// Name of the relayer to use for the link.
+	Relayer string `yaml:"relayer"`
+
+	// Name of path to create.
+	Path string `yaml:"path"`
+
+	// Options for creating the clients and channel, defaulting as described by InterchainLink.
+	CreateClientOpts  ibc.CreateClientOptions `yaml:"create-client-opts"`
+	CreateChannelOpts TopologyChannelOptions  `yaml:"create-channel-opts"`
+}
+
+// TopologyChannelOptions mirrors ibc.CreateChannelOptions, with the channel order written as "ordered" or "unordered".
+// Options that are not set default to those of ibc.DefaultChannelOpts.
+type TopologyChannelOptions struct {
+	SourcePortName string `yaml:"source-port-name"`
+	DestPortName   string `yaml:"dest-port-name"`
+	Order          string `yaml:"order"`
+	Version        string `yaml:"version"`
+}
+
+// LoadTopology reads the JSON or YAML topology file at path.
+func LoadTopology(path string) (*Topology, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read topology file: %w", err)
+	}
+
+	t, err := ParseTopology(bz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse topology file %s: %w", path, err)
+	}
+	return t, nil
+}
+
+// ParseTopology parses a YAML or JSON topology.
+// Unknown fields are rejected, so that misspelled options are not silently ignored.
+func ParseTopology(bz []byte) (*Topology, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(bz))
+	dec.KnownFields(true)
+
+	var t Topology
+	if err := dec.Decode(&t); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// LoadInterchain reads the topology file at path and returns its Interchain, ready to Build.
+// See Topology.Interchain.
+func LoadInterchain(log *zap.Logger, t TestName, cli *client.Client, networkID, path string) (*Interchain, error) {
+	topology, err := LoadTopology(path)
+	if err != nil {
+		return nil, err
+	}
+	return topology.Interchain(log, t, cli, networkID)
+}
+
+// Interchain builds the chains and relayers of the topology and returns an Interchain linking them, ready to Build.
+// The chains and relayers can be retrieved by name with Interchain.Chain and Interchain.Relayer.
+func (t *Topology) Interchain(log *zap.Logger, testName TestName, cli *client.Client, networkID string) (*Interchain, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+
+	specs := make([]*ChainSpec, len(t.Chains))
+	for i, c := range t.Chains {
+		// The chain factory modifies the specs it is given, so it gets copies to keep the topology reusable.
+		spec := c.clone()
+		// Links refer to chains by name, so chain names must not be suffixed like those of ChainSpecs without ChainName.
+		if spec.ChainName == "" {
+			spec.ChainName = spec.Name
+		}
+		if spec.ChainName == "" {
+			spec.ChainName = spec.ChainConfig.Name
+		}
+		specs[i] = spec
+	}
+
+	chains, err := NewBuiltinChainFactory(log, specs).Chains(testName.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	ic := NewInterchain().WithLog(log)
+
+	chainsByName := make(map[string]ibc.Chain, len(chains))
+	chainIDs := make(map[string]string, len(chains))
+	for i, chain := range chains {
+		cfg := chain.Config()
+		if _, exists := chainsByName[cfg.Name]; exists {
+			return nil, fmt.Errorf("a chain with name %s already exists", cfg.Name)
+		}
+		if name, exists := chainIDs[cfg.ChainID]; exists {
+			return nil, fmt.Errorf("chains %s and %s have the same chain ID %s", name, cfg.Name, cfg.ChainID)
+		}
+		chainsByName[cfg.Name] = chain
+		chainIDs[cfg.ChainID] = cfg.Name
+
+		wallets, err := t.Chains[i].genesisWallets()
+		if err != nil {
+			return nil, fmt.Errorf("invalid genesis wallets for chain %s: %w", cfg.Name, err)
+		}
+		ic.AddChain(chain, wallets...)
+	}
+
+	relayersByName := make(map[string]ibc.Relayer, len(t.Relayers))
+	for _, r := range t.Relayers {
+		impl, err := relayerImplementation(r.Impl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid relayer %s: %w", r.Name, err)
+		}
+
+		rel := NewBuiltinRelayerFactory(impl, log, r.options()...).Build(testName, cli, networkID)
+		relayersByName[r.Name] = rel
+		ic.AddRelayer(rel, r.Name)
+	}
+
+	for _, l := range t.Links {
+		chain1, ok := chainsByName[l.Chain1]
+		if !ok {
+			return nil, fmt.Errorf("link %s references unknown chain %s", l.Path, l.Chain1)
+		}
+		chain2, ok := chainsByName[l.Chain2]
+		if !ok {
+			return nil, fmt.Errorf("link %s references unknown chain %s", l.Path, l.Chain2)
+		}
+
+		channelOpts, err := l.CreateChannelOpts.options()
+		if err != nil {
+			return nil, fmt.Errorf("invalid channel options for link %s: %w", l.Path, err)
+		}
+
+		ic.AddLink(InterchainLink{
+			Chain1:            chain1,
+			Chain2:            chain2,
+			Relayer:           relayersByName[l.Relayer],
+			Path:              l.Path,
+			CreateClientOpts:  l.CreateClientOpts,
+			CreateChannelOpts: channelOpts,
+		})
+	}
+
+	return ic, nil
+}
+
+// Validate checks the references between the entries of the topology.
+// Chain names are checked when the chains are built, since they may be derived from built-in configs.
+func (t *Topology) Validate() error {
+	for i, c := range t.Chains {
+		if c.ChainSpec == nil {
+			return fmt.Errorf("chain at index %d is empty", i)
+		}
+	}
+
+	relayers := make(map[string]struct{}, len(t.Relayers))
+	for _, r := range t.Relayers {
+		if r.Name == "" {
+			return fmt.Errorf("relayer name must not be empty")
+		}
+		if _, exists := relayers[r.Name]; exists {
+			return fmt.Errorf("a relayer with name %s already exists", r.Name)
+		}
+		relayers[r.Name] = struct{}{}
+	}
+
+	paths := make(map[[2]string]struct{}, len(t.Links))
+	for _, l := range t.Links {
+		if _, exists := relayers[l.Relayer]; !exists {
+			return fmt.Errorf("link %s references unknown relayer %s", l.Path, l.Relayer)
+		}
+		if l.Chain1 == l.Chain2 {
+			return fmt.Errorf("link %s: chains must be different (both were %s)", l.Path, l.Chain1)
+		}
+
+		key := [2]string{l.Relayer, l.Path}
+		if _, exists := paths[key]; exists {
+			return fmt.Errorf("relayer %q already has a path named %q", l.Relayer, l.Path)
+		}
+		paths[key] = struct{}{}
+	}
+
+	return nil
+}
+
+func (c TopologyChain) genesisWallets() ([]ibc.WalletAmount, error) {
+	wallets := make([]ibc.WalletAmount, len(c.GenesisWallets))
+	for i, w := range c.GenesisWallets {
+		amount, ok := math.NewIntFromString(w.Amount)
+		if !ok {
+			return nil, fmt.Errorf("invalid amount %q for wallet %s", w.Amount, w.Address)
+		}
+		wallets[i] = ibc.WalletAmount{
+			Address: w.Address,
+			Denom:   w.Denom,
+			Amount:  amount,
+		}
+	}
+	return wallets, nil
+}
+
+func (r TopologyRelayer) options() []relayer.RelayerOpt {
+	var opts []relayer.RelayerOpt
+	if r.Image != nil {
+		opts = append(opts, relayer.DockerImage(r.Image))
+	}
+	if r.HomeDir != "" {
+		opts = append(opts, relayer.HomeDir(r.HomeDir))
+	}
+	if len(r.StartupFlags) > 0 {
+		opts = append(opts, relayer.StartupFlags(r.StartupFlags...))
+	}
+	if r.ImagePull != nil {
+		opts = append(opts, relayer.ImagePull(*r.ImagePull))
+	}
+	return opts
+}
+
+func (o TopologyChannelOptions) options() (ibc.CreateChannelOptions, error) {
+	// Unset options take their default values, rather than only defaulting when no option is set.
+	opts := ibc.DefaultChannelOpts()
+	if o.SourcePortName != "" {
+		opts.SourcePortName = o.SourcePortName
+	}
+	if o.DestPortName != "" {
+		opts.DestPortName = o.DestPortName
+	}
+	if o.Version != "" {
+		opts.Version = o.Version
+	}
+
+	switch o.Order {
+	case "":
+	case ibc.Ordered.String():
+		opts.Order = ibc.Ordered
+	case ibc.Unordered.String():
+		opts.Order = ibc.Unordered
+	default:
+		return ibc.CreateChannelOptions{}, fmt.Errorf("unknown channel order %q (valid orders: ordered, unordered)", o.Order)
+	}
+
+	return opts, nil
+}
+
+// relayerImplementation returns the relayer implementation with the given name.
+func relayerImplementation(name string) (ibc.RelayerImplementation, error) {
+	switch name {
+	case "rly", "cosmos/relayer":
+		return ibc.CosmosRly, nil
+	case "hermes":
+		return ibc.Hermes, nil
+	case "hyperspace":
+		return ibc.Hyperspace, nil
+	default:
+		return 0, fmt.Errorf("unknown relayer implementation %q (valid implementations: rly, hermes, hyperspace)", name)
+	}
+}
@@ -0,0 +1,203 @@
+package interchaintest_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+const topologyYAML = `
+chains:
+  - name: gaia
+    chain-name: gaia-1
+    version: v7.0.1
+    num-validators: 1
+    genesis-wallets:
+      - {address: cosmos1abc, denom: uatom, amount: 1000000}
+      - {address: cosmos1def, denom: uatom, amount: 100000000000000000000000}
+  - name: osmosis
+    version: v11.0.1
+    chain-config: {chain-id: osmosis-1001, gas-prices: 0.0025uosmo}
+relayers:
+  - name: rly
+    impl: rly
+    startup-flags: ["-b", "100"]
+links:
+  - chain1: gaia-1
+    chain2: osmosis
+    relayer: rly
+    path: gaia-osmo
+    create-client-opts: {trusting-period: 330h}
+    create-channel-opts: {order: ordered}
+`
+
+const topologyJSON = `{
+  "chains": [
+    {
+      "name": "gaia",
+      "chain-name": "gaia-1",
+      "version": "v7.0.1",
+      "num-validators": 1,
+      "genesis-wallets": [
+        {"address": "cosmos1abc", "denom": "uatom", "amount": 1000000},
+        {"address": "cosmos1def", "denom": "uatom", "amount": 100000000000000000000000}
+      ]
+    },
+    {"name": "osmosis", "version": "v11.0.1", "chain-config": {"chain-id": "osmosis-1001", "gas-prices": "0.0025uosmo"}}
+  ],
+  "relayers": [{"name": "rly", "impl": "rly", "startup-flags": ["-b", "100"]}],
+  "links": [
+    {
+      "chain1": "gaia-1",
+      "chain2": "osmosis",
+      "relayer": "rly",
+      "path": "gaia-osmo",
+      "create-client-opts": {"trusting-period": "330h"},
+      "create-channel-opts": {"order": "ordered"}
+    }
+  ]
+}`
+
+func TestParseTopology(t *testing.T) {
+	for name, bz := range map[string]string{"yaml": topologyYAML, "json": topologyJSON} {
+		bz := bz
+		t.Run(name, func(t *testing.T) {
+			topology, err := interchaintest.ParseTopology([]byte(bz))
+			require.NoError(t, err)
+			require.NoError(t, topology.Validate())
+
+			require.Len(t, topology.Chains, 2)
+			gaia := topology.Chains[0]
+			require.Equal(t, "gaia", gaia.Name)
+			require.Equal(t, "gaia-1", gaia.ChainName)
+			require.Equal(t, "v7.0.1", gaia.Version)
+			require.Equal(t, 1, *gaia.NumValidators)
+			require.Nil(t, gaia.NumFullNodes)
+			require.Len(t, gaia.GenesisWallets, 2)
+			require.Equal(t, "1000000", gaia.GenesisWallets[0].Amount)
+			require.Equal(t, "100000000000000000000000", gaia.GenesisWallets[1].Amount)
+			osmosis := topology.Chains[1]
+			require.Equal(t, "osmosis", osmosis.Name)
+			require.Equal(t, "osmosis-1001", osmosis.ChainID)
+			require.Equal(t, "0.0025uosmo", osmosis.GasPrices)
+
+			require.Len(t, topology.Relayers, 1)
+			require.Equal(t, "rly", topology.Relayers[0].Impl)
+			require.Equal(t, []string{"-b", "100"}, topology.Relayers[0].StartupFlags)
+
+			require.Len(t, topology.Links, 1)
+			link := topology.Links[0]
+			require.Equal(t, "gaia-1", link.Chain1)
+			require.Equal(t, "osmosis", link.Chain2)
+			require.Equal(t, "gaia-osmo", link.Path)
+			require.Equal(t, "330h", link.CreateClientOpts.TrustingPeriod)
+			require.Equal(t, "ordered", link.CreateChannelOpts.Order)
+		})
+	}
+}
+
+func TestLoadTopology(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "topology.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(topologyYAML), 0o600))
+
+	topology, err := interchaintest.LoadTopology(path)
+	require.NoError(t, err)
+	require.Len(t, topology.Chains, 2)
+
+	_, err = interchaintest.LoadTopology(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+}
+
+func TestParseTopology_UnknownField(t *testing.T) {
+	_, err := interchaintest.ParseTopology([]byte(`
+chains:
+  - name: gaia
+    chain-id: gaia-1
+`))
+	require.ErrorContains(t, err, "field chain-id not found")
+}
+
+func TestTopology_Interchain(t *testing.T) {
+	topology, err := interchaintest.ParseTopology([]byte(`
+chains:
+  - name: gaia
+    version: v7.0.1
+  - name: osmosis
+    version: v11.0.1
+`))
+	require.NoError(t, err)
+
+	// The topology is left untouched, so it can build any number of interchains.
+	for i := 0; i < 2; i++ {
+		ic, err := topology.Interchain(zaptest.NewLogger(t), t, nil, "")
+		require.NoError(t, err)
+		_, ok := ic.Chain("gaia")
+		require.True(t, ok)
+		_, ok = ic.Chain("osmosis")
+		require.True(t, ok)
+
+		for _, c := range topology.Chains {
+			require.Empty(t, c.ChainName)
+			require.Empty(t, c.ChainConfig.Name)
+			require.Empty(t, c.ChainID)
+		}
+	}
+}
+
+func TestTopology_Validate(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		topology string
+		wantErr  string
+	}{
+		{
+			name: "unknown relayer",
+			topology: `
+links:
+  - {chain1: a, chain2: b, relayer: rly, path: p}
+`,
+			wantErr: "unknown relayer rly",
+		},
+		{
+			name: "duplicate relayer",
+			topology: `
+relayers:
+  - {name: rly, impl: rly}
+  - {name: rly, impl: hermes}
+`,
+			wantErr: "relayer with name rly already exists",
+		},
+		{
+			name: "same chains",
+			topology: `
+relayers:
+  - {name: rly, impl: rly}
+links:
+  - {chain1: a, chain2: a, relayer: rly, path: p}
+`,
+			wantErr: "chains must be different",
+		},
+		{
+			name: "duplicate path",
+			topology: `
+relayers:
+  - {name: rly, impl: rly}
+links:
+  - {chain1: a, chain2: b, relayer: rly, path: p}
+  - {chain1: a, chain2: c, relayer: rly, path: p}
+`,
+			wantErr: `already has a path named "p"`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			topology, err := interchaintest.ParseTopology([]byte(tt.topology))
+			require.NoError(t, err)
+			require.ErrorContains(t, topology.Validate(), tt.wantErr)
+		})
+	}
+}